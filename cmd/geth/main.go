@@ -73,20 +73,31 @@ var (
 		utils.OverrideOptimismInterop,
 		utils.EnablePersonal,
 		utils.TxPoolLocalsFlag,
+		utils.TxPoolPriorityFlag,
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
 		utils.TxPoolJournalRemotesFlag,
 		utils.TxPoolRejournalFlag,
+		utils.TxPoolTrackerJournalFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
+		utils.TxPoolPriceBumpModeFlag,
+		utils.TxPoolPriceBumpWeiFlag,
 		utils.TxPoolAccountSlotsFlag,
 		utils.TxPoolGlobalSlotsFlag,
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolQueueTTLFlag,
+		utils.TxPoolShedLatencyFlag,
+		utils.TxPoolShedHeapFlag,
+		utils.TxPoolUnderpricedSizeFlag,
+		utils.TxPoolUnderpricedTimeoutFlag,
 		utils.BlobPoolDataDirFlag,
 		utils.BlobPoolDataCapFlag,
 		utils.BlobPoolPriceBumpFlag,
+		utils.BlobPoolPriceBumpModeFlag,
+		utils.BlobPoolPriceBumpWeiFlag,
 		utils.SyncModeFlag,
 		utils.SyncTargetFlag,
 		utils.ExitWhenSyncedFlag,
@@ -129,6 +140,8 @@ var (
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerPendingFeeRecipientFlag,
+		utils.MinerMaxTxsPerSenderFlag,
+		utils.MinerSenderCapExemptFlag,
 		utils.MinerNewPayloadTimeoutFlag, // deprecated
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
@@ -42,7 +42,10 @@ import (
 	"github.com/ethereum/go-ethereum/common/fdlimit"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -50,6 +53,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/tracers"
@@ -375,6 +379,11 @@ var (
 		Usage:    "Comma separated accounts to treat as locals (no flush, priority inclusion)",
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolPriorityFlag = &cli.StringFlag{
+		Name:     "txpool.priority",
+		Usage:    "Comma separated accounts exempt from per-account limits and included first in blocks",
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolNoLocalsFlag = &cli.BoolFlag{
 		Name:     "txpool.nolocals",
 		Usage:    "Disables price exemptions for locally submitted transactions",
@@ -397,6 +406,12 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolTrackerJournalFlag = &cli.StringFlag{
+		Name:     "txpool.trackerjournal",
+		Usage:    "Disk journal for tracked local transactions to survive node restarts (empty to disable)",
+		Value:    ethconfig.Defaults.TxTracker.Journal,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -409,6 +424,18 @@ var (
 		Value:    ethconfig.Defaults.TxPool.PriceBump,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolPriceBumpModeFlag = &cli.StringFlag{
+		Name:     "txpool.pricebumpmode",
+		Usage:    `Rule to replace an already existing transaction, "percent" (see --txpool.pricebump) or "absolute" (see --txpool.pricebumpwei)`,
+		Value:    ethconfig.Defaults.TxPool.PriceBumpMode,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolPriceBumpWeiFlag = &cli.Uint64Flag{
+		Name:     "txpool.pricebumpwei",
+		Usage:    "Price bump in wei per gas to replace an already existing transaction in absolute mode",
+		Value:    ethconfig.Defaults.TxPool.PriceBumpWei,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolAccountSlotsFlag = &cli.Uint64Flag{
 		Name:     "txpool.accountslots",
 		Usage:    "Minimum number of executable transaction slots guaranteed per account",
@@ -439,6 +466,36 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolQueueTTLFlag = &cli.DurationFlag{
+		Name:     "txpool.queuettl",
+		Usage:    "Maximum time an individual transaction may wait in the queue, regardless of account activity (0 = disabled)",
+		Value:    ethconfig.Defaults.TxPool.QueueTTL,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolShedLatencyFlag = &cli.DurationFlag{
+		Name:     "txpool.shedlatency",
+		Usage:    "Average transaction admission latency above which remote transactions are rejected (0 = disabled)",
+		Value:    ethconfig.Defaults.TxPool.ShedLatency,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolShedHeapFlag = &cli.Uint64Flag{
+		Name:     "txpool.shedheap",
+		Usage:    "Heap size in megabytes above which remote transactions are rejected (0 = disabled)",
+		Value:    ethconfig.Defaults.TxPool.ShedHeap,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolUnderpricedSizeFlag = &cli.IntFlag{
+		Name:     "txpool.underpricedsize",
+		Usage:    "Maximum number of transactions remembered as underpriced to avoid re-fetching them",
+		Value:    ethconfig.Defaults.TxUnderpriced.Size,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolUnderpricedTimeoutFlag = &cli.DurationFlag{
+		Name:     "txpool.underpricedtimeout",
+		Usage:    "Time after which a transaction rejected as underpriced may be fetched again",
+		Value:    ethconfig.Defaults.TxUnderpriced.Timeout,
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -458,6 +515,18 @@ var (
 		Value:    ethconfig.Defaults.BlobPool.PriceBump,
 		Category: flags.BlobPoolCategory,
 	}
+	BlobPoolPriceBumpModeFlag = &cli.StringFlag{
+		Name:     "blobpool.pricebumpmode",
+		Usage:    `Rule to replace an already existing blob transaction, "percent" (see --blobpool.pricebump) or "absolute" (see --blobpool.pricebumpwei)`,
+		Value:    ethconfig.Defaults.BlobPool.PriceBumpMode,
+		Category: flags.BlobPoolCategory,
+	}
+	BlobPoolPriceBumpWeiFlag = &cli.Uint64Flag{
+		Name:     "blobpool.pricebumpwei",
+		Usage:    "Price bump in wei per gas to replace an already existing blob transaction in absolute mode",
+		Value:    ethconfig.Defaults.BlobPool.PriceBumpWei,
+		Category: flags.BlobPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -552,6 +621,18 @@ var (
 		Usage:    "0x prefixed public address for the pending block producer (not used for actual block production)",
 		Category: flags.MinerCategory,
 	}
+	MinerMaxTxsPerSenderFlag = &cli.Uint64Flag{
+		Name:     "miner.maxtxspersender",
+		Usage:    "Maximum number of transactions from a single sender included in a block (0 = unlimited)",
+		Value:    ethconfig.Defaults.Miner.MaxTxsPerSender,
+		Category: flags.MinerCategory,
+	}
+	MinerSenderCapExemptFlag = &cli.StringFlag{
+		Name:     "miner.sendercapexempt",
+		Usage:    "Comma separated accounts exempt from the per-sender inclusion cap",
+		Value:    "",
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	UnlockedAccountFlag = &cli.StringFlag{
@@ -1633,6 +1714,9 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 			}
 		}
 	}
+	if ctx.IsSet(TxPoolPriorityFlag.Name) {
+		cfg.Priority = parseAccounts(ctx, TxPoolPriorityFlag.Name)
+	}
 	if ctx.IsSet(TxPoolNoLocalsFlag.Name) {
 		cfg.NoLocals = ctx.Bool(TxPoolNoLocalsFlag.Name)
 	}
@@ -1651,6 +1735,12 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(TxPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(TxPoolPriceBumpModeFlag.Name) {
+		cfg.PriceBumpMode = parsePriceBumpMode(ctx, TxPoolPriceBumpModeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPriceBumpWeiFlag.Name) {
+		cfg.PriceBumpWei = ctx.Uint64(TxPoolPriceBumpWeiFlag.Name)
+	}
 	if ctx.IsSet(TxPoolAccountSlotsFlag.Name) {
 		cfg.AccountSlots = ctx.Uint64(TxPoolAccountSlotsFlag.Name)
 	}
@@ -1666,6 +1756,15 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolQueueTTLFlag.Name) {
+		cfg.QueueTTL = ctx.Duration(TxPoolQueueTTLFlag.Name)
+	}
+	if ctx.IsSet(TxPoolShedLatencyFlag.Name) {
+		cfg.ShedLatency = ctx.Duration(TxPoolShedLatencyFlag.Name)
+	}
+	if ctx.IsSet(TxPoolShedHeapFlag.Name) {
+		cfg.ShedHeap = ctx.Uint64(TxPoolShedHeapFlag.Name)
+	}
 	if ctx.IsSet(MinerEffectiveGasLimitFlag.Name) {
 		// While technically this is a miner config parameter, we also want the txpool to enforce
 		// it to avoid accepting transactions that can never be included in a block.
@@ -1673,6 +1772,42 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	}
 }
 
+func setTxTracker(ctx *cli.Context, cfg *locals.Config) {
+	if ctx.IsSet(TxPoolTrackerJournalFlag.Name) {
+		cfg.Journal = ctx.String(TxPoolTrackerJournalFlag.Name)
+	}
+}
+
+func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
+	if ctx.IsSet(BlobPoolPriceBumpModeFlag.Name) {
+		cfg.PriceBumpMode = parsePriceBumpMode(ctx, BlobPoolPriceBumpModeFlag.Name)
+	}
+	if ctx.IsSet(BlobPoolPriceBumpWeiFlag.Name) {
+		cfg.PriceBumpWei = ctx.Uint64(BlobPoolPriceBumpWeiFlag.Name)
+	}
+}
+
+// parsePriceBumpMode validates the replacement rule of the given flag, aborting
+// on an unknown mode.
+func parsePriceBumpMode(ctx *cli.Context, name string) string {
+	switch mode := ctx.String(name); mode {
+	case txpool.PriceBumpPercent, txpool.PriceBumpAbsolute:
+		return mode
+	default:
+		Fatalf("Invalid mode in --%s: %s (want %q or %q)", name, mode, txpool.PriceBumpPercent, txpool.PriceBumpAbsolute)
+		return ""
+	}
+}
+
+func setTxUnderpriced(ctx *cli.Context, cfg *fetcher.UnderpricedConfig) {
+	if ctx.IsSet(TxPoolUnderpricedSizeFlag.Name) {
+		cfg.Size = ctx.Int(TxPoolUnderpricedSizeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolUnderpricedTimeoutFlag.Name) {
+		cfg.Timeout = ctx.Duration(TxPoolUnderpricedTimeoutFlag.Name)
+	}
+}
+
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.Bool(MiningEnabledFlag.Name) {
 		log.Warn("The flag --mine is deprecated and will be removed")
@@ -1699,6 +1834,31 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(RollupComputePendingBlock.Name) {
 		cfg.RollupComputePendingBlock = ctx.Bool(RollupComputePendingBlock.Name)
 	}
+	if ctx.IsSet(TxPoolPriorityFlag.Name) {
+		// While technically this is a txpool config parameter, we also want the miner
+		// to include these accounts' transactions ahead of everything else.
+		cfg.PrioritySenders = parseAccounts(ctx, TxPoolPriorityFlag.Name)
+	}
+	if ctx.IsSet(MinerMaxTxsPerSenderFlag.Name) {
+		cfg.MaxTxsPerSender = ctx.Uint64(MinerMaxTxsPerSenderFlag.Name)
+	}
+	if ctx.IsSet(MinerSenderCapExemptFlag.Name) {
+		cfg.SenderCapExempt = parseAccounts(ctx, MinerSenderCapExemptFlag.Name)
+	}
+}
+
+// parseAccounts parses the comma separated account list of the given flag,
+// aborting on any malformed entry.
+func parseAccounts(ctx *cli.Context, name string) []common.Address {
+	var accounts []common.Address
+	for _, account := range strings.Split(ctx.String(name), ",") {
+		if trimmed := strings.TrimSpace(account); !common.IsHexAddress(trimmed) {
+			Fatalf("Invalid account in --%s: %s", name, trimmed)
+		} else {
+			accounts = append(accounts, common.HexToAddress(trimmed))
+		}
+	}
+	return accounts
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1780,6 +1940,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setEtherbase(ctx, cfg)
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
+	setBlobPool(ctx, &cfg.BlobPool)
+	setTxTracker(ctx, &cfg.TxTracker)
+	setTxUnderpriced(ctx, &cfg.TxUnderpriced)
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
 	setLes(ctx, cfg)
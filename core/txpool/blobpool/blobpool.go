@@ -314,6 +314,8 @@ type BlobPool struct {
 	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
 	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
 
+	validators []txpool.TxValidator // External admission hooks, run after the built-in checks
+
 	lock sync.RWMutex // Mutex protecting the pool during reorg handling
 }
 
@@ -1076,6 +1078,16 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 	p.updateStorageMetrics()
 }
 
+// RegisterValidator adds an external admission hook that every new transaction
+// must pass before being accepted into the pool. Hooks are not retroactively
+// applied to already pooled transactions.
+func (p *BlobPool) RegisterValidator(validator txpool.TxValidator) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.validators = append(p.validators, validator)
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (p *BlobPool) validateTx(tx *types.Transaction) error {
@@ -1120,6 +1132,7 @@ func (p *BlobPool) validateTx(tx *types.Transaction) error {
 			}
 			return nil
 		},
+		Validators: p.validators,
 	}
 	if err := txpool.ValidateTransactionWithState(tx, p.signer, stateOpts); err != nil {
 		return err
@@ -1137,29 +1150,13 @@ func (p *BlobPool) validateTx(tx *types.Transaction) error {
 			return txpool.ErrAlreadyKnown
 		}
 		// Account can support the replacement, but the price bump must also be met
-		switch {
-		case tx.GasFeeCapIntCmp(prev.execFeeCap.ToBig()) <= 0:
-			return fmt.Errorf("%w: new tx gas fee cap %v <= %v queued", txpool.ErrReplaceUnderpriced, tx.GasFeeCap(), prev.execFeeCap)
-		case tx.GasTipCapIntCmp(prev.execTipCap.ToBig()) <= 0:
-			return fmt.Errorf("%w: new tx gas tip cap %v <= %v queued", txpool.ErrReplaceUnderpriced, tx.GasTipCap(), prev.execTipCap)
-		case tx.BlobGasFeeCapIntCmp(prev.blobFeeCap.ToBig()) <= 0:
-			return fmt.Errorf("%w: new tx blob gas fee cap %v <= %v queued", txpool.ErrReplaceUnderpriced, tx.BlobGasFeeCap(), prev.blobFeeCap)
+		old := &txpool.ReplacementFees{
+			GasFeeCap:  prev.execFeeCap,
+			GasTipCap:  prev.execTipCap,
+			BlobFeeCap: prev.blobFeeCap,
 		}
-		var (
-			multiplier = uint256.NewInt(100 + p.config.PriceBump)
-			onehundred = uint256.NewInt(100)
-
-			minGasFeeCap     = new(uint256.Int).Div(new(uint256.Int).Mul(multiplier, prev.execFeeCap), onehundred)
-			minGasTipCap     = new(uint256.Int).Div(new(uint256.Int).Mul(multiplier, prev.execTipCap), onehundred)
-			minBlobGasFeeCap = new(uint256.Int).Div(new(uint256.Int).Mul(multiplier, prev.blobFeeCap), onehundred)
-		)
-		switch {
-		case tx.GasFeeCapIntCmp(minGasFeeCap.ToBig()) < 0:
-			return fmt.Errorf("%w: new tx gas fee cap %v <= %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasFeeCap(), prev.execFeeCap, p.config.PriceBump)
-		case tx.GasTipCapIntCmp(minGasTipCap.ToBig()) < 0:
-			return fmt.Errorf("%w: new tx gas tip cap %v <= %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasTipCap(), prev.execTipCap, p.config.PriceBump)
-		case tx.BlobGasFeeCapIntCmp(minBlobGasFeeCap.ToBig()) < 0:
-			return fmt.Errorf("%w: new tx blob gas fee cap %v <= %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.BlobGasFeeCap(), prev.blobFeeCap, p.config.PriceBump)
+		if err := p.config.PriceBumpPolicy.Replaceable(old, txpool.NewReplacementFees(tx, nil)); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -1639,6 +1636,14 @@ func (p *BlobPool) ContentFrom(addr common.Address) ([]*types.Transaction, []*ty
 	return []*types.Transaction{}, []*types.Transaction{}
 }
 
+// ContentRange retrieves at most limit transactions passing the filter, in
+// content order and starting after the given position.
+//
+// For the blob pool, this method will return nothing for now, same as Content.
+func (p *BlobPool) ContentRange(after *txpool.ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []txpool.ContentItem {
+	return nil
+}
+
 // Locals retrieves the accounts currently considered local by the pool.
 //
 // There is no notion of local accounts in the blob pool.
@@ -1313,6 +1313,56 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+// Tests that registered admission hooks are consulted for blob transactions and
+// that a rejection leaves the pool untouched.
+func TestAddValidators(t *testing.T) {
+	storage, _ := os.MkdirTemp("", "blobpool-")
+	defer os.RemoveAll(storage)
+
+	var (
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+
+		addr1 = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2 = crypto.PubkeyToAddress(key2.PublicKey)
+
+		errBanned = errors.New("sender banned")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewDatabase(memorydb.New())), nil)
+	statedb.AddBalance(addr1, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.AddBalance(addr2, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true)
+
+	chain := &testBlockChain{
+		config:  testChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: storage}, chain)
+	if err := pool.Init(1, chain.CurrentBlock(), makeAddressReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	pool.RegisterValidator(txpool.TxValidatorFunc(func(tx *types.Transaction, from common.Address, state *state.StateDB) error {
+		if from == addr2 {
+			return errBanned
+		}
+		return nil
+	}))
+	if err := pool.add(makeTx(0, 1, 1000, 100, key1)); err != nil {
+		t.Errorf("failed to add allowed transaction: %v", err)
+	}
+	if err := pool.add(makeTx(0, 1, 1000, 100, key2)); !errors.Is(err, errBanned) {
+		t.Errorf("banned transaction error mismatch: have %v, want %v", err, errBanned)
+	}
+	if _, ok := pool.index[addr2]; ok {
+		t.Errorf("rejected account %v present in pool", addr2)
+	}
+	verifyPoolInternals(t, pool)
+}
+
 // Benchmarks the time it takes to assemble the lazy pending transaction list
 // from the pool contents.
 func BenchmarkPoolPending100Mb(b *testing.B) { benchmarkPoolPending(b, 100_000_000) }
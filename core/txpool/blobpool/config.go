@@ -17,6 +17,7 @@
 package blobpool
 
 import (
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -25,6 +26,13 @@ type Config struct {
 	Datadir   string // Data directory containing the currently executable blobs
 	Datacap   uint64 // Soft-cap of database storage (hard cap is larger due to overhead)
 	PriceBump uint64 // Minimum price bump percentage to replace an already existing nonce
+
+	PriceBumpMode string // Replacement rule, either a "percent" bump of PriceBump or an "absolute" one of PriceBumpWei
+	PriceBumpWei  uint64 // Minimum price bump in wei per gas to replace an already existing nonce in absolute mode
+
+	// PriceBumpPolicy overrides the replace-by-fee rule of the pool. If nil, it
+	// is built from PriceBumpMode and enforced on all fee caps.
+	PriceBumpPolicy txpool.PriceBumpPolicy `toml:"-"`
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -32,6 +40,8 @@ var DefaultConfig = Config{
 	Datadir:   "blobpool",
 	Datacap:   10 * 1024 * 1024 * 1024 / 4, // TODO(karalabe): /4 handicap for rollout, gradually bump back up to 10GB
 	PriceBump: 100,                         // either have patience or be aggressive, no mushy ground
+
+	PriceBumpMode: txpool.PriceBumpPercent,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -46,5 +56,21 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid blobpool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
 		conf.PriceBump = DefaultConfig.PriceBump
 	}
+	if conf.PriceBumpMode == "" {
+		conf.PriceBumpMode = DefaultConfig.PriceBumpMode
+	}
+	if conf.PriceBumpMode == txpool.PriceBumpAbsolute && conf.PriceBumpWei < 1 {
+		log.Warn("Sanitizing invalid blobpool absolute price bump", "provided", conf.PriceBumpWei, "updated", 1)
+		conf.PriceBumpWei = 1
+	}
+	if conf.PriceBumpPolicy == nil {
+		policy, err := txpool.NewPriceBumpPolicy(conf.PriceBumpMode, conf.PriceBump, conf.PriceBumpWei)
+		if err != nil {
+			log.Warn("Sanitizing invalid blobpool price bump mode", "provided", conf.PriceBumpMode, "updated", DefaultConfig.PriceBumpMode)
+			conf.PriceBumpMode = DefaultConfig.PriceBumpMode
+			policy = txpool.PercentageBump(conf.PriceBump)
+		}
+		conf.PriceBumpPolicy = policy
+	}
 	return conf
 }
@@ -60,4 +60,9 @@ var (
 	// input transaction of non-blob type when a blob transaction from this sender
 	// remains pending (and vice-versa).
 	ErrAlreadyReserved = errors.New("address already reserved")
+
+	// ErrOverloaded is returned if a remote transaction is rejected because the
+	// pool is shedding load. It is not a statement about the transaction itself,
+	// which may be resubmitted once the pool recovered.
+	ErrOverloaded = errors.New("txpool overloaded")
 )
@@ -0,0 +1,362 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+)
+
+const (
+	// journalVersion is the current version of the journal file format. Version
+	// 1 (the legacy format) is a plain stream of RLP encoded transactions without
+	// any header; version 2 starts with journalMagic and the version byte and is
+	// followed by a sequence of segments.
+	//
+	// Each segment is an 8 byte header containing the big endian length and the
+	// CRC32-C checksum of the payload, followed by the payload itself, which is a
+	// snappy compressed RLP list of transactions.
+	journalVersion = 2
+
+	// journalSegmentSize is the amount of RLP encoded transaction data after
+	// which a rotation starts a new segment.
+	journalSegmentSize = 1024 * 1024
+
+	// journalMaxSegment is the maximum compressed size of a segment accepted on
+	// load. Anything above is treated as corruption of the segment header.
+	journalMaxSegment = 64 * 1024 * 1024
+)
+
+var (
+	// errNoActiveJournal is returned if a transaction is attempted to be inserted
+	// into the journal, but no such file is currently open.
+	errNoActiveJournal = errors.New("no active journal")
+
+	// errJournalSegmentCorrupt is returned if a journal segment was fully read
+	// but its contents failed the checksum or could not be decoded.
+	errJournalSegmentCorrupt = errors.New("corrupt journal segment")
+
+	// errJournalSegmentOversized is returned if a journal segment header reports
+	// a size above journalMaxSegment, meaning the rest of the file is unusable.
+	errJournalSegmentOversized = errors.New("oversized journal segment")
+
+	// journalMagic is the file prefix identifying a versioned journal. It can't
+	// collide with the legacy format as a transaction is never a single byte.
+	journalMagic = []byte("gtxj")
+
+	// journalCRCTable is the CRC32 table used to checksum the journal segments.
+	journalCRCTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// devNull is a WriteCloser that just discards anything written into it. Its
+// goal is to allow the transaction journal to write into a fake journal when
+// loading transactions on startup without printing warnings due to no file
+// being read for write.
+type devNull struct{}
+
+func (*devNull) Write(p []byte) (n int, err error) { return len(p), nil }
+func (*devNull) Close() error                      { return nil }
+
+// Journal is a rotating log of transactions with the aim of storing locally
+// created transactions to allow non-executed ones to survive node restarts.
+type Journal struct {
+	path   string         // Filesystem path to store the transactions at
+	writer io.WriteCloser // Output stream to write new transactions into
+}
+
+// NewJournal creates a new transaction journal backed by the file at path.
+func NewJournal(path string) *Journal {
+	return &Journal{
+		path: path,
+	}
+}
+
+// Load parses a transaction journal dump from disk, feeding its contents into
+// the specified add callback.
+func (journal *Journal) Load(add func([]*types.Transaction) []error) error {
+	// Open the journal for loading any past transactions
+	input, err := os.Open(journal.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Skip the parsing if the journal file doesn't exist at all
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	// Temporarily discard any journal additions (don't double add on load)
+	journal.writer = new(devNull)
+	defer func() { journal.writer = nil }()
+
+	// Detect the format of the journal, falling back to the legacy RLP stream
+	// if no version header is found
+	read, err := journalReader(input)
+	if err != nil {
+		return err
+	}
+	// Inject all transactions from the journal into the pool
+	total, dropped, corrupted := 0, 0, 0
+
+	// Create a method to load a limited batch of transactions and bump the
+	// appropriate progress counters. Then use this method to load all the
+	// journaled transactions in small-ish batches.
+	loadBatch := func(txs types.Transactions) {
+		for _, err := range add(txs) {
+			if err != nil {
+				log.Debug("Failed to add journaled transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	var (
+		failure error
+		batch   types.Transactions
+	)
+	for {
+		// Parse the next transactions and terminate on error
+		txs, err := read()
+		if errors.Is(err, errJournalSegmentCorrupt) {
+			// The segment was skipped in its entirety, keep loading the rest
+			log.Warn("Skipping corrupt transaction journal segment", "err", err)
+			corrupted++
+			continue
+		}
+		if err != nil {
+			switch {
+			case errors.Is(err, io.ErrUnexpectedEOF):
+				// A torn write at the tail of the journal, the transactions
+				// before it are still valid
+				log.Warn("Discarding torn transaction journal tail", "path", journal.path)
+			case err != io.EOF:
+				failure = err
+			}
+			if batch.Len() > 0 {
+				loadBatch(batch)
+			}
+			break
+		}
+		// New transactions parsed, queue up for later, import if threshold is reached
+		total += len(txs)
+
+		if batch = append(batch, txs...); batch.Len() > 1024 {
+			loadBatch(batch)
+			batch = batch[:0]
+		}
+	}
+	if corrupted > 0 {
+		log.Warn("Loaded transaction journal", "path", journal.path, "transactions", total, "dropped", dropped, "corrupted", corrupted)
+	} else {
+		log.Info("Loaded transaction journal", "path", journal.path, "transactions", total, "dropped", dropped)
+	}
+	return failure
+}
+
+// journalReader inspects the header of a journal file and returns an iterator
+// over its transactions. Legacy journals are read transaction by transaction,
+// versioned ones segment by segment.
+func journalReader(input io.ReadSeeker) (func() (types.Transactions, error), error) {
+	header := make([]byte, len(journalMagic)+1)
+	_, err := io.ReadFull(input, header)
+	if err == io.EOF {
+		// Empty journal, nothing to load
+		return func() (types.Transactions, error) { return nil, io.EOF }, nil
+	}
+	if err == nil && bytes.Equal(header[:len(journalMagic)], journalMagic) {
+		if version := header[len(journalMagic)]; version != journalVersion {
+			return nil, fmt.Errorf("unsupported journal version %d", version)
+		}
+		reader := bufio.NewReader(input)
+		return func() (types.Transactions, error) {
+			return readJournalSegment(reader)
+		}, nil
+	}
+	// No version header found, rewind and parse as legacy RLP stream. The
+	// journal is regenerated in the current format on the next rotation.
+	if _, err := input.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	log.Info("Migrating legacy transaction journal", "version", journalVersion)
+
+	stream := rlp.NewStream(input, 0)
+	return func() (types.Transactions, error) {
+		tx := new(types.Transaction)
+		if err := stream.Decode(tx); err != nil {
+			return nil, err
+		}
+		return types.Transactions{tx}, nil
+	}, nil
+}
+
+// readJournalSegment reads the next segment out of a versioned journal. If the
+// journal ends mid-segment, io.ErrUnexpectedEOF is returned. Segments failing
+// their checksum are consumed and reported via errJournalSegmentCorrupt.
+func readJournalSegment(r io.Reader) (types.Transactions, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:4])
+	if size > journalMaxSegment {
+		return nil, fmt.Errorf("%w: %d bytes", errJournalSegmentOversized, size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if have, want := crc32.Checksum(data, journalCRCTable), binary.BigEndian.Uint32(header[4:]); have != want {
+		return nil, fmt.Errorf("%w: checksum mismatch: have %#x, want %#x", errJournalSegmentCorrupt, have, want)
+	}
+	blob, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errJournalSegmentCorrupt, err)
+	}
+	var txs types.Transactions
+	if err := rlp.DecodeBytes(blob, &txs); err != nil {
+		return nil, fmt.Errorf("%w: %v", errJournalSegmentCorrupt, err)
+	}
+	return txs, nil
+}
+
+// writeJournalSegment compresses and checksums a batch of RLP encoded
+// transactions and writes it out as a single segment.
+func writeJournalSegment(w io.Writer, blobs [][]byte) error {
+	buf := rlp.NewEncoderBuffer(nil)
+	list := buf.List()
+	for _, blob := range blobs {
+		buf.Write(blob)
+	}
+	buf.ListEnd(list)
+	data := snappy.Encode(nil, buf.ToBytes())
+
+	// Write the header and payload with a single call to minimize the chance
+	// of a torn segment
+	segment := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint32(segment[:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(segment[4:], crc32.Checksum(data, journalCRCTable))
+	segment = append(segment, data...)
+
+	_, err := w.Write(segment)
+	return err
+}
+
+// Insert adds the specified transaction to the disk journal.
+func (journal *Journal) Insert(tx *types.Transaction) error {
+	if journal.writer == nil {
+		return errNoActiveJournal
+	}
+	blob, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	return writeJournalSegment(journal.writer, [][]byte{blob})
+}
+
+// Rotate regenerates the transaction journal based on the given transactions.
+func (journal *Journal) Rotate(all map[common.Address]types.Transactions) error {
+	// Close the current journal (if any is open)
+	if journal.writer != nil {
+		if err := journal.writer.Close(); err != nil {
+			return err
+		}
+		journal.writer = nil
+	}
+	// Generate a new journal with the contents of the current pool
+	replacement, err := os.OpenFile(journal.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = replacement.Write(append(journalMagic, journalVersion)); err != nil {
+		replacement.Close()
+		return err
+	}
+	var (
+		journaled int
+		blobs     [][]byte
+		size      int
+	)
+	for _, txs := range all {
+		for _, tx := range txs {
+			blob, err := rlp.EncodeToBytes(tx)
+			if err != nil {
+				replacement.Close()
+				return err
+			}
+			blobs, size = append(blobs, blob), size+len(blob)
+			if size >= journalSegmentSize {
+				if err = writeJournalSegment(replacement, blobs); err != nil {
+					replacement.Close()
+					return err
+				}
+				blobs, size = blobs[:0], 0
+			}
+		}
+		journaled += len(txs)
+	}
+	if len(blobs) > 0 {
+		if err = writeJournalSegment(replacement, blobs); err != nil {
+			replacement.Close()
+			return err
+		}
+	}
+	replacement.Close()
+
+	// Replace the live journal with the newly generated one
+	if err = os.Rename(journal.path+".new", journal.path); err != nil {
+		return err
+	}
+	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	journal.writer = sink
+
+	logger := log.Info
+	if len(all) == 0 {
+		logger = log.Debug
+	}
+	logger("Regenerated transaction journal", "path", journal.path, "transactions", journaled, "accounts", len(all))
+
+	return nil
+}
+
+// Close flushes the transaction journal contents to disk and closes the file.
+func (journal *Journal) Close() error {
+	var err error
+
+	if journal.writer != nil {
+		err = journal.writer.Close()
+		journal.writer = nil
+	}
+	return err
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// transaction creates a signed legacy transaction to store in a journal.
+func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) *types.Transaction {
+	tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{}, big.NewInt(100), gaslimit, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+	return tx
+}
+
+// loadJournal reads back all the transactions from the journal at path.
+func loadJournal(t *testing.T, path string) (types.Transactions, error) {
+	t.Helper()
+
+	var loaded types.Transactions
+	err := NewJournal(path).Load(func(txs []*types.Transaction) []error {
+		loaded = append(loaded, txs...)
+		return make([]error, len(txs))
+	})
+	return loaded, err
+}
+
+// checkJournal ensures the loaded transactions match the expected ones.
+func checkJournal(t *testing.T, have, want types.Transactions) {
+	t.Helper()
+
+	if len(have) != len(want) {
+		t.Fatalf("loaded transaction count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i].Hash() != want[i].Hash() {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, have[i].Hash(), want[i].Hash())
+		}
+	}
+}
+
+// Tests that transactions written by rotations and insertions survive a reload.
+func TestJournalRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	txs := types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key), transaction(2, 100000, key)}
+
+	journal := NewJournal(path)
+	if err := journal.Rotate(map[common.Address]types.Transactions{addr: txs[:2]}); err != nil {
+		t.Fatalf("failed to rotate journal: %v", err)
+	}
+	if err := journal.Insert(txs[2]); err != nil {
+		t.Fatalf("failed to insert into journal: %v", err)
+	}
+	if err := journal.Close(); err != nil {
+		t.Fatalf("failed to close journal: %v", err)
+	}
+	loaded, err := loadJournal(t, path)
+	if err != nil {
+		t.Fatalf("failed to load journal: %v", err)
+	}
+	checkJournal(t, loaded, txs)
+}
+
+// Tests that a torn write at the end of the journal only loses the partially
+// written segment and that corrupt segments are skipped.
+func TestJournalCorruption(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	txs := types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key), transaction(2, 100000, key)}
+
+	journal := NewJournal(path)
+	if err := journal.Rotate(map[common.Address]types.Transactions{addr: txs[:1]}); err != nil {
+		t.Fatalf("failed to rotate journal: %v", err)
+	}
+	for _, tx := range txs[1:] {
+		if err := journal.Insert(tx); err != nil {
+			t.Fatalf("failed to insert into journal: %v", err)
+		}
+	}
+	journal.Close()
+
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	// Tear the last segment and ensure the preceding ones are still loaded
+	if err := os.WriteFile(path, blob[:len(blob)-3], 0644); err != nil {
+		t.Fatalf("failed to tear journal: %v", err)
+	}
+	loaded, err := loadJournal(t, path)
+	if err != nil {
+		t.Fatalf("failed to load torn journal: %v", err)
+	}
+	checkJournal(t, loaded, txs[:2])
+
+	// Flip a bit in the first segment's payload and ensure only it is skipped
+	blob[len(journalMagic)+1+8] ^= 0x01
+	if err := os.WriteFile(path, blob, 0644); err != nil {
+		t.Fatalf("failed to corrupt journal: %v", err)
+	}
+	loaded, err = loadJournal(t, path)
+	if err != nil {
+		t.Fatalf("failed to load corrupt journal: %v", err)
+	}
+	checkJournal(t, loaded, txs[1:])
+}
+
+// Tests that journals in the legacy format are loaded and rewritten in the
+// current format on the next rotation.
+func TestJournalMigration(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	txs := types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}
+
+	var legacy []byte
+	for _, tx := range txs {
+		blob, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			t.Fatalf("failed to encode transaction: %v", err)
+		}
+		legacy = append(legacy, blob...)
+	}
+	if err := os.WriteFile(path, legacy, 0644); err != nil {
+		t.Fatalf("failed to write legacy journal: %v", err)
+	}
+	loaded, err := loadJournal(t, path)
+	if err != nil {
+		t.Fatalf("failed to load legacy journal: %v", err)
+	}
+	checkJournal(t, loaded, txs)
+
+	journal := NewJournal(path)
+	if err := journal.Rotate(map[common.Address]types.Transactions{addr: loaded}); err != nil {
+		t.Fatalf("failed to rotate journal: %v", err)
+	}
+	journal.Close()
+
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if len(blob) < len(journalMagic)+1 || string(blob[:len(journalMagic)]) != string(journalMagic) || blob[len(journalMagic)] != journalVersion {
+		t.Fatalf("rotated journal missing version header: %x", blob)
+	}
+	if loaded, err = loadJournal(t, path); err != nil {
+		t.Fatalf("failed to load migrated journal: %v", err)
+	}
+	checkJournal(t, loaded, txs)
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	rmetrics "runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// heapSampleInterval is the minimum time between two heap size readings, as
+	// they are not free and the heap does not move much within a second.
+	heapSampleInterval = time.Second
+
+	// breakerWaitWeight is the weight of a new lock wait sample in the moving
+	// average the breaker trips on.
+	breakerWaitWeight = 0.1
+)
+
+var (
+	shedMeter       = metrics.NewRegisteredMeter("txpool/shed/rejected", nil)
+	shedTripMeter   = metrics.NewRegisteredMeter("txpool/shed/trips", nil)
+	shedActiveGauge = metrics.NewRegisteredGauge("txpool/shed/active", nil)
+	shedWaitGauge   = metrics.NewRegisteredGauge("txpool/shed/wait", nil)
+	shedHeapGauge   = metrics.NewRegisteredGauge("txpool/shed/heap", nil)
+)
+
+// breaker is the admission circuit breaker of the pool. It tracks how long the
+// insertion of transactions waits for the pool lock and how large the heap is,
+// tripping when either exceeds its threshold so that remote transactions can be
+// shed until the pool catches up again.
+//
+// To avoid flapping, a tripped breaker only resets once the lock wait dropped
+// below half its threshold and the heap below 90% of its.
+type breaker struct {
+	maxWait time.Duration // Lock wait average above which to trip (0 = disabled)
+	maxHeap uint64        // Heap size in bytes above which to trip (0 = disabled)
+
+	readHeap func() uint64 // Heap size reader, replaceable for tests
+
+	wait     float64   // Moving average of the lock wait in nanoseconds
+	heap     uint64    // Last sampled heap size
+	sampled  time.Time // Time of the last heap sample
+	shedding bool      // Whether the breaker is tripped
+	lock     sync.Mutex
+}
+
+// newBreaker creates an admission breaker with the given thresholds. A zero
+// threshold disables the respective check.
+func newBreaker(maxWait time.Duration, maxHeap uint64) *breaker {
+	return &breaker{
+		maxWait:  maxWait,
+		maxHeap:  maxHeap,
+		readHeap: readHeapObjects,
+	}
+}
+
+// enabled returns whether any of the thresholds is configured.
+func (b *breaker) enabled() bool {
+	return b.maxWait > 0 || b.maxHeap > 0
+}
+
+// observe feeds the time a pool operation had to wait for the lock into the
+// breaker and reevaluates its state.
+func (b *breaker) observe(wait time.Duration) {
+	if !b.enabled() {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.wait += breakerWaitWeight * (float64(wait) - b.wait)
+	shedWaitGauge.Update(int64(b.wait))
+
+	b.update(time.Now())
+}
+
+// tripped returns whether remote transactions should currently be rejected.
+func (b *breaker) tripped() bool {
+	if !b.enabled() {
+		return false
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.update(time.Now())
+	return b.shedding
+}
+
+// update samples the heap if sufficient time passed since the last reading and
+// trips or resets the breaker according to the current load. The lock must be
+// held.
+func (b *breaker) update(now time.Time) {
+	if b.maxHeap > 0 && now.Sub(b.sampled) >= heapSampleInterval {
+		b.heap, b.sampled = b.readHeap(), now
+		shedHeapGauge.Update(int64(b.heap))
+	}
+	var (
+		wait = time.Duration(b.wait)
+		over = (b.maxWait > 0 && wait > b.maxWait) || (b.maxHeap > 0 && b.heap > b.maxHeap)
+		calm = (b.maxWait == 0 || wait < b.maxWait/2) && (b.maxHeap == 0 || b.heap < b.maxHeap/10*9)
+	)
+	switch {
+	case !b.shedding && over:
+		log.Warn("Transaction pool overloaded, shedding remote transactions", "wait", wait, "heap", b.heap)
+		b.shedding = true
+		shedTripMeter.Mark(1)
+		shedActiveGauge.Update(1)
+
+	case b.shedding && calm:
+		log.Info("Transaction pool recovered, accepting remote transactions", "wait", wait, "heap", b.heap)
+		b.shedding = false
+		shedActiveGauge.Update(0)
+	}
+}
+
+// readHeapObjects returns the number of bytes occupied by live and not yet
+// swept heap objects.
+func readHeapObjects() uint64 {
+	sample := []rmetrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	rmetrics.Read(sample)
+	if sample[0].Value.Kind() != rmetrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -52,6 +53,10 @@ const (
 	// more expensive to propagate; larger transactions also take more resources
 	// to validate whether they fit into the pool or not.
 	txMaxSize = 4 * txSlotSize // 128KB
+
+	// validationCacheSize is the number of static validation results retained
+	// to avoid rechecking transactions submitted again to the pool.
+	validationCacheSize = 16384
 )
 
 var (
@@ -78,6 +83,8 @@ var (
 	queuedRateLimitMeter = metrics.NewRegisteredMeter("txpool/queued/ratelimit", nil) // Dropped due to rate limiting
 	queuedNofundsMeter   = metrics.NewRegisteredMeter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 	queuedEvictionMeter  = metrics.NewRegisteredMeter("txpool/queued/eviction", nil)  // Dropped due to lifetime
+	queuedTTLMeter       = metrics.NewRegisteredMeter("txpool/queued/ttl", nil)       // Dropped due to queue TTL
+	queuedGapGauge       = metrics.NewRegisteredGauge("txpool/queued/gap", nil)       // Seconds the longest waiting non-local queued tx has been stuck in the queue
 
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
@@ -101,6 +108,13 @@ var (
 	slotsGauge   = metrics.NewRegisteredGauge("txpool/slots", nil)
 
 	reheapTimer = metrics.NewRegisteredTimer("txpool/reheap", nil)
+
+	// resetPrepareTimer measures the part of a pool reset spent outside the pool lock.
+	resetPrepareTimer = metrics.NewRegisteredTimer("txpool/reset/prepare", nil)
+	// resetIncrementalMeter counts the resets only revalidating the accounts
+	// touched by the new head, resetFullMeter the ones revalidating everything.
+	resetIncrementalMeter = metrics.NewRegisteredMeter("txpool/reset/incremental", nil)
+	resetFullMeter        = metrics.NewRegisteredMeter("txpool/reset/full", nil)
 )
 
 // BlockChain defines the minimal set of methods needed to back a tx pool with
@@ -122,6 +136,7 @@ type BlockChain interface {
 // Config are the configuration parameters of the transaction pool.
 type Config struct {
 	Locals    []common.Address // Addresses that should be treated by default as local
+	Priority  []common.Address // Addresses exempt from per-account slot limits (priority lane)
 	NoLocals  bool             // Whether local transaction handling should be disabled
 	Journal   string           // Journal of local transactions to survive node restarts
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
@@ -130,8 +145,19 @@ type Config struct {
 	// When true, all transactions loaded from the journal are treated as remote.
 	JournalRemote bool
 
-	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
-	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
+	PriceLimit    uint64 // Minimum gas price to enforce for acceptance into the pool
+	PriceBump     uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
+	PriceBumpMode string // Replacement rule, either a "percent" bump of PriceBump or an "absolute" one of PriceBumpWei
+	PriceBumpWei  uint64 // Minimum price bump in wei per gas to replace an already existing transaction in absolute mode
+
+	// PriceBumpPolicy overrides the replace-by-fee rule of the pool. If nil, it
+	// is built from PriceBumpMode.
+	PriceBumpPolicy txpool.PriceBumpPolicy `toml:"-"`
+
+	// Scoring overrides the ranking of remote transactions for eviction. If nil,
+	// transactions are ranked by their effective tip, net of the L1 data cost
+	// on rollups.
+	Scoring txpool.ScoringPolicy `toml:"-"`
 
 	AccountSlots uint64 // Number of executable transaction slots guaranteed per account
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
@@ -139,8 +165,16 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+	QueueTTL time.Duration // Maximum time an individual transaction may wait in the queue, regardless of account activity (0 = disabled)
 
 	EffectiveGasCeil uint64 // if non-zero, a gas ceiling to enforce independent of the header's gaslimit value
+
+	// ShedLatency and ShedHeap are the overload thresholds above which remote
+	// transactions are rejected with txpool.ErrOverloaded, while local ones are
+	// still accepted. The latency is the average time insertions wait for the
+	// pool lock, the heap is measured in megabytes. Zero disables the check.
+	ShedLatency time.Duration
+	ShedHeap    uint64
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -148,8 +182,9 @@ var DefaultConfig = Config{
 	Journal:   "transactions.rlp",
 	Rejournal: time.Hour,
 
-	PriceLimit: 1,
-	PriceBump:  10,
+	PriceLimit:    1,
+	PriceBump:     10,
+	PriceBumpMode: txpool.PriceBumpPercent,
 
 	AccountSlots: 16,
 	GlobalSlots:  4096 + 1024, // urgent + floating queue capacity with 4:1 ratio
@@ -175,6 +210,22 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
 		conf.PriceBump = DefaultConfig.PriceBump
 	}
+	if conf.PriceBumpMode == "" {
+		conf.PriceBumpMode = DefaultConfig.PriceBumpMode
+	}
+	if conf.PriceBumpMode == txpool.PriceBumpAbsolute && conf.PriceBumpWei < 1 {
+		log.Warn("Sanitizing invalid txpool absolute price bump", "provided", conf.PriceBumpWei, "updated", 1)
+		conf.PriceBumpWei = 1
+	}
+	if conf.PriceBumpPolicy == nil {
+		policy, err := txpool.NewPriceBumpPolicy(conf.PriceBumpMode, conf.PriceBump, conf.PriceBumpWei)
+		if err != nil {
+			log.Warn("Sanitizing invalid txpool price bump mode", "provided", conf.PriceBumpMode, "updated", DefaultConfig.PriceBumpMode)
+			conf.PriceBumpMode = DefaultConfig.PriceBumpMode
+			policy = txpool.PercentageBump(conf.PriceBump)
+		}
+		conf.PriceBumpPolicy = policy
+	}
 	if conf.AccountSlots < 1 {
 		log.Warn("Sanitizing invalid txpool account slots", "provided", conf.AccountSlots, "updated", DefaultConfig.AccountSlots)
 		conf.AccountSlots = DefaultConfig.AccountSlots
@@ -195,9 +246,25 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if conf.QueueTTL < 0 {
+		log.Warn("Sanitizing invalid txpool queue TTL", "provided", conf.QueueTTL, "updated", time.Duration(0))
+		conf.QueueTTL = 0
+	}
+	if conf.ShedLatency < 0 {
+		log.Warn("Sanitizing invalid txpool shed latency", "provided", conf.ShedLatency, "updated", time.Duration(0))
+		conf.ShedLatency = 0
+	}
 	return conf
 }
 
+// Limits are the slot limits of the pool which may be adjusted at runtime.
+type Limits struct {
+	AccountSlots uint64 `json:"accountSlots"` // Number of executable transaction slots guaranteed per account
+	GlobalSlots  uint64 `json:"globalSlots"`  // Maximum number of executable transaction slots for all accounts
+	AccountQueue uint64 `json:"accountQueue"` // Maximum number of non-executable transaction slots permitted per account
+	GlobalQueue  uint64 `json:"globalQueue"`  // Maximum number of non-executable transaction slots for all accounts
+}
+
 // LegacyPool contains all currently known transactions. Transactions
 // enter the pool when they are received from the network or submitted
 // locally. They exit the pool when they are included in the blockchain.
@@ -218,16 +285,22 @@ type LegacyPool struct {
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 
-	locals  *accountSet // Set of local transaction to exempt from eviction rules
-	journal *journal    // Journal of local transaction to back up to disk
+	locals   *accountSet     // Set of local transaction to exempt from eviction rules
+	priority *accountSet     // Set of priority accounts to exempt from per-account limits
+	journal  *txpool.Journal // Journal of local transaction to back up to disk
 
 	reserve txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
 	pending map[common.Address]*list     // All currently processable transactions
 	queue   map[common.Address]*list     // Queued but non-processable transactions
 	beats   map[common.Address]time.Time // Last heartbeat from each known account
+	entered map[common.Hash]time.Time    // Time each queued transaction entered the queue
+	churn   map[common.Address]*churn    // Transaction acceptance counters of each known account
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	validation *txpool.ValidationCache // Memo of the static admission checks of seen transactions
+	breaker    *breaker                // Admission breaker shedding remote transactions under overload
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -238,13 +311,55 @@ type LegacyPool struct {
 
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
 
-	l1CostFn txpool.L1CostFunc // To apply L1 costs as rollup, optional field, may be nil.
+	l1CostFn    txpool.L1CostFunc // To apply L1 costs as rollup, optional field, may be nil.
+	l1FeeParams l1FeeParams       // L1 fee parameters of the current head, to detect L1 cost changes
+
+	validators []txpool.TxValidator // External admission hooks, run after the built-in checks
 }
 
 type txpoolResetRequest struct {
 	oldHead, newHead *types.Header
 }
 
+// churn counts the transactions accepted from an account since it was first
+// seen by the pool, including replacements.
+type churn struct {
+	since time.Time
+	added uint64
+}
+
+// rate returns the average number of transactions accepted per minute.
+func (c *churn) rate() float64 {
+	return float64(c.added) / max(time.Since(c.since).Minutes(), 1)
+}
+
+// SenderStats summarizes the transactions and recent activity of a single
+// account in the pool.
+type SenderStats struct {
+	Address common.Address `json:"address"`
+	Pending int            `json:"pending"` // Number of executable transactions
+	Queued  int            `json:"queued"`  // Number of non-executable transactions
+	Gas     uint64         `json:"gas"`     // Aggregate gas limit of all pooled transactions
+	Churn   float64        `json:"churn"`   // Transactions accepted per minute while the account is pooled
+}
+
+// l1FeeParams are the rollup fee parameters stored in the L1 block info contract.
+// If they are unchanged between two heads, so are the L1 costs of transactions.
+type l1FeeParams [5]common.Hash
+
+// resetPlan is the result of the preparatory phase of a pool reset, which runs
+// without holding the pool lock.
+type resetPlan struct {
+	oldHead, newHead *types.Header
+
+	state       *state.StateDB
+	l1CostFn    txpool.L1CostFunc
+	l1FeeParams l1FeeParams
+
+	reinject types.Transactions // Transactions discarded by a reorg to reinject
+	touched  *accountSet        // Senders of the new head's transactions, nil if the head does not directly extend the old one
+}
+
 // New creates a new transaction pool to gather, sort and filter inbound
 // transactions from the network.
 func New(config Config, chain BlockChain) *LegacyPool {
@@ -260,7 +375,11 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		pending:         make(map[common.Address]*list),
 		queue:           make(map[common.Address]*list),
 		beats:           make(map[common.Address]time.Time),
+		entered:         make(map[common.Hash]time.Time),
+		churn:           make(map[common.Address]*churn),
 		all:             newLookup(),
+		validation:      txpool.NewValidationCache(validationCacheSize),
+		breaker:         newBreaker(config.ShedLatency, config.ShedHeap*1024*1024),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
 		queueTxEventCh:  make(chan *types.Transaction),
@@ -273,10 +392,15 @@ func New(config Config, chain BlockChain) *LegacyPool {
 		log.Info("Setting new local account", "address", addr)
 		pool.locals.add(addr)
 	}
-	pool.priced = newPricedList(pool.all)
+	pool.priority = newAccountSet(pool.signer, config.Priority...)
+	scoring := config.Scoring
+	if scoring == nil && chain.Config().Optimism != nil {
+		scoring = txpool.NetTipScoring()
+	}
+	pool.priced = newPricedList(pool.all, scoring)
 
 	if (!config.NoLocals || config.JournalRemote) && config.Journal != "" {
-		pool.journal = newTxJournal(config.Journal)
+		pool.journal = txpool.NewJournal(config.Journal)
 	}
 	return pool
 }
@@ -324,14 +448,15 @@ func (pool *LegacyPool) Init(gasTip uint64, head *types.Header, reserve txpool.A
 
 	// If local transactions and journaling is enabled, load from disk
 	if pool.journal != nil {
-		add := pool.addLocals
-		if pool.config.JournalRemote {
-			add = pool.addRemotesSync // Use sync version to match pool.AddLocals
+		// Reloaded transactions are not new submissions, keep them out of the churn
+		local := !pool.config.NoLocals && !pool.config.JournalRemote
+		add := func(txs []*types.Transaction) []error {
+			return pool.addTxs(txs, local, true, false)
 		}
-		if err := pool.journal.load(add); err != nil {
+		if err := pool.journal.Load(add); err != nil {
 			log.Warn("Failed to load transaction journal", "err", err)
 		}
-		if err := pool.journal.rotate(pool.toJournal()); err != nil {
+		if err := pool.journal.Rotate(pool.toJournal()); err != nil {
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
@@ -381,6 +506,7 @@ func (pool *LegacyPool) loop() {
 		// Handle inactive account transaction eviction
 		case <-evict.C:
 			pool.mu.Lock()
+			var oldest time.Duration
 			for addr := range pool.queue {
 				// Skip local transactions from the eviction mechanism
 				if pool.locals.contains(addr) {
@@ -393,6 +519,34 @@ func (pool *LegacyPool) loop() {
 						pool.removeTx(tx.Hash(), true, true)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
+					continue
+				}
+				// The account is still active, drop any individual transactions
+				// that have been stuck behind a nonce gap for too long
+				if age := pool.evictStaleQueued(addr); age > oldest {
+					oldest = age
+				}
+			}
+			queuedGapGauge.Update(int64(oldest / time.Second))
+
+			// Forget the queue entry times of transactions which left the queue
+			// without going through removeTx or promoteTx
+			for hash := range pool.entered {
+				tx := pool.all.Get(hash)
+				if tx == nil {
+					delete(pool.entered, hash)
+					continue
+				}
+				from, _ := types.Sender(pool.signer, tx) // already validated
+				if list := pool.queue[from]; list == nil || list.txs.Get(tx.Nonce()) != tx {
+					delete(pool.entered, hash)
+				}
+			}
+
+			// Forget the activity of accounts no longer in the pool
+			for addr := range pool.churn {
+				if pool.pending[addr] == nil && pool.queue[addr] == nil {
+					delete(pool.churn, addr)
 				}
 			}
 			pool.mu.Unlock()
@@ -401,7 +555,7 @@ func (pool *LegacyPool) loop() {
 		case <-journal.C:
 			if pool.journal != nil {
 				pool.mu.Lock()
-				if err := pool.journal.rotate(pool.toJournal()); err != nil {
+				if err := pool.journal.Rotate(pool.toJournal()); err != nil {
 					log.Warn("Failed to rotate local tx journal", "err", err)
 				}
 				pool.mu.Unlock()
@@ -417,7 +571,7 @@ func (pool *LegacyPool) Close() error {
 	pool.wg.Wait()
 
 	if pool.journal != nil {
-		pool.journal.close()
+		pool.journal.Close()
 	}
 	log.Info("Transaction pool stopped")
 	return nil
@@ -463,6 +617,111 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 	log.Info("Legacy pool tip threshold updated", "tip", newTip)
 }
 
+// RegisterValidator adds an external admission hook that every new transaction
+// must pass before being accepted into the pool. Hooks are not retroactively
+// applied to already pooled transactions.
+func (pool *LegacyPool) RegisterValidator(validator txpool.TxValidator) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.validators = append(pool.validators, validator)
+}
+
+// TopSenders returns the stats of the n accounts with the most transactions in
+// the pool, ties broken by aggregate gas. A non-positive n returns all accounts.
+func (pool *LegacyPool) TopSenders(n int) []SenderStats {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	stats := make(map[common.Address]*SenderStats)
+	get := func(addr common.Address) *SenderStats {
+		if stats[addr] == nil {
+			stats[addr] = &SenderStats{Address: addr}
+			if c := pool.churn[addr]; c != nil {
+				stats[addr].Churn = c.rate()
+			}
+		}
+		return stats[addr]
+	}
+	for addr, list := range pool.pending {
+		stat := get(addr)
+		for _, tx := range list.Flatten() {
+			stat.Pending++
+			stat.Gas += tx.Gas()
+		}
+	}
+	for addr, list := range pool.queue {
+		stat := get(addr)
+		for _, tx := range list.Flatten() {
+			stat.Queued++
+			stat.Gas += tx.Gas()
+		}
+	}
+	senders := make([]SenderStats, 0, len(stats))
+	for _, stat := range stats {
+		senders = append(senders, *stat)
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		if ci, cj := senders[i].Pending+senders[i].Queued, senders[j].Pending+senders[j].Queued; ci != cj {
+			return ci > cj
+		}
+		if senders[i].Gas != senders[j].Gas {
+			return senders[i].Gas > senders[j].Gas
+		}
+		return senders[i].Address.Cmp(senders[j].Address) < 0
+	})
+	if n > 0 && len(senders) > n {
+		senders = senders[:n]
+	}
+	return senders
+}
+
+// Limits retrieves the currently enforced slot limits of the pool.
+func (pool *LegacyPool) Limits() Limits {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return Limits{
+		AccountSlots: pool.config.AccountSlots,
+		GlobalSlots:  pool.config.GlobalSlots,
+		AccountQueue: pool.config.AccountQueue,
+		GlobalQueue:  pool.config.GlobalQueue,
+	}
+}
+
+// SetLimits updates the slot limits of the pool, leaving any zero fields of the
+// given limits unchanged. If the limits shrink, excess transactions are evicted
+// following the usual fairness rules before the method returns.
+func (pool *LegacyPool) SetLimits(limits Limits) Limits {
+	pool.mu.Lock()
+	if limits.AccountSlots != 0 {
+		pool.config.AccountSlots = limits.AccountSlots
+	}
+	if limits.GlobalSlots != 0 {
+		pool.config.GlobalSlots = limits.GlobalSlots
+	}
+	if limits.AccountQueue != 0 {
+		pool.config.AccountQueue = limits.AccountQueue
+	}
+	if limits.GlobalQueue != 0 {
+		pool.config.GlobalQueue = limits.GlobalQueue
+	}
+	// Schedule all queued accounts for promotion to enforce the per-account queue
+	// limit, the global limits are always enforced at the end of the reorg.
+	dirty := newAccountSet(pool.signer)
+	for addr := range pool.queue {
+		dirty.add(addr)
+	}
+	pool.mu.Unlock()
+
+	<-pool.requestPromoteExecutables(dirty)
+
+	updated := pool.Limits()
+	log.Info("Legacy pool limits updated", "accountslots", updated.AccountSlots, "globalslots", updated.GlobalSlots,
+		"accountqueue", updated.AccountQueue, "globalqueue", updated.GlobalQueue)
+	return updated
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (pool *LegacyPool) Nonce(addr common.Address) uint64 {
@@ -529,6 +788,64 @@ func (pool *LegacyPool) ContentFrom(addr common.Address) ([]*types.Transaction,
 	return pending, queued
 }
 
+// ContentRange retrieves at most limit transactions passing the filter, ordered
+// by status (pending first), sender and nonce and starting after the given
+// position (nil for the start). If sender is set, only the transactions of that
+// account are considered.
+//
+// Contrary to Content, only the accounts after the position are visited and the
+// walk stops as soon as the limit is reached, so paging through a large pool
+// does not snapshot all of it for every page.
+func (pool *LegacyPool) ContentRange(after *txpool.ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []txpool.ContentItem {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var items []txpool.ContentItem
+	for _, section := range []struct {
+		queued bool
+		lists  map[common.Address]*list
+	}{{false, pool.pending}, {true, pool.queue}} {
+		// Skip the sections and accounts fully served before the position
+		var resume *txpool.ContentCursor
+		if after != nil {
+			if after.Queued && !section.queued {
+				continue
+			}
+			if after.Queued == section.queued {
+				resume = after
+			}
+		}
+		var senders []common.Address
+		if sender != nil {
+			if _, ok := section.lists[*sender]; ok && (resume == nil || sender.Cmp(resume.Sender) >= 0) {
+				senders = append(senders, *sender)
+			}
+		} else {
+			for addr := range section.lists {
+				if resume == nil || addr.Cmp(resume.Sender) >= 0 {
+					senders = append(senders, addr)
+				}
+			}
+			slices.SortFunc(senders, common.Address.Cmp)
+		}
+		for _, addr := range senders {
+			for _, tx := range section.lists[addr].Flatten() {
+				if resume != nil && addr == resume.Sender && tx.Nonce() <= resume.Nonce {
+					continue
+				}
+				if filter != nil && !filter(tx) {
+					continue
+				}
+				items = append(items, txpool.ContentItem{Queued: section.queued, Sender: addr, Tx: tx})
+				if len(items) == limit {
+					return items
+				}
+			}
+		}
+	}
+	return items
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -642,6 +959,7 @@ func (pool *LegacyPool) validateTxBasics(tx *types.Transaction, local bool) erro
 		MaxSize:          txMaxSize,
 		MinTip:           pool.gasTip.Load().ToBig(),
 		EffectiveGasCeil: pool.config.EffectiveGasCeil,
+		Cache:            pool.validation,
 	}
 	if local {
 		opts.MinTip = new(big.Int)
@@ -689,7 +1007,8 @@ func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 			}
 			return nil
 		},
-		L1CostFn: pool.l1CostFn,
+		L1CostFn:   pool.l1CostFn,
+		Validators: pool.validators,
 	}
 	if err := txpool.ValidateTransactionWithState(tx, pool.signer, opts); err != nil {
 		return err
@@ -812,7 +1131,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 	// Try to replace an existing transaction in the pending pool
 	if list := pool.pending[from]; list != nil && list.Contains(tx.Nonce()) {
 		// Nonce already pending, check if required price bump is met
-		inserted, old := list.Add(tx, pool.config.PriceBump, pool.l1CostFn)
+		inserted, old := list.Add(tx, pool.config.PriceBumpPolicy, pool.l1CostFn)
 		if !inserted {
 			pendingDiscardMeter.Mark(1)
 			return false, txpool.ErrReplaceUnderpriced
@@ -886,7 +1205,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	if pool.queue[from] == nil {
 		pool.queue[from] = newList(false)
 	}
-	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump, pool.l1CostFn)
+	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBumpPolicy, pool.l1CostFn)
 	if !inserted {
 		// An older transaction was better, discard this
 		queuedDiscardMeter.Mark(1)
@@ -896,6 +1215,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 	if old != nil {
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
+		delete(pool.entered, old.Hash())
 		queuedReplaceMeter.Mark(1)
 	} else {
 		// Nothing was replaced, bump the queued counter
@@ -910,6 +1230,11 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 		pool.all.Add(tx, local)
 		pool.priced.Put(tx, local)
 	}
+	// Stamp the queue entry to age the transaction from, keeping the original
+	// one if it has been queued before
+	if _, exist := pool.entered[hash]; !exist {
+		pool.entered[hash] = time.Now()
+	}
 	// If we never record the heartbeat, do it right now.
 	if _, exist := pool.beats[from]; !exist {
 		pool.beats[from] = time.Now()
@@ -924,7 +1249,7 @@ func (pool *LegacyPool) journalTx(from common.Address, tx *types.Transaction) {
 	if pool.journal == nil || (!pool.config.JournalRemote && !pool.locals.contains(from)) {
 		return
 	}
-	if err := pool.journal.insert(tx); err != nil {
+	if err := pool.journal.Insert(tx); err != nil {
 		log.Warn("Failed to journal local transaction", "err", err)
 	}
 }
@@ -934,13 +1259,15 @@ func (pool *LegacyPool) journalTx(from common.Address, tx *types.Transaction) {
 //
 // Note, this method assumes the pool lock is held!
 func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *types.Transaction) bool {
+	delete(pool.entered, hash)
+
 	// Try to insert the transaction into the pending queue
 	if pool.pending[addr] == nil {
 		pool.pending[addr] = newList(true)
 	}
 	list := pool.pending[addr]
 
-	inserted, old := list.Add(tx, pool.config.PriceBump, pool.l1CostFn)
+	inserted, old := list.Add(tx, pool.config.PriceBumpPolicy, pool.l1CostFn)
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
@@ -1005,12 +1332,51 @@ func (pool *LegacyPool) addRemoteSync(tx *types.Transaction) error {
 	return pool.Add([]*types.Transaction{tx}, false, true)[0]
 }
 
+// evictStaleQueued removes the queued transactions of an account which have been
+// waiting in the queue for longer than the configured queue TTL, returning the
+// longest wait of the ones remaining. The wait is measured from the time a
+// transaction last entered the queue, so transactions demoted from pending start
+// over instead of being aged by their first sighting.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) evictStaleQueued(addr common.Address) time.Duration {
+	var (
+		oldest  time.Duration
+		evicted int
+	)
+	for _, tx := range pool.queue[addr].Flatten() {
+		entered, ok := pool.entered[tx.Hash()]
+		if !ok {
+			entered = time.Now()
+			pool.entered[tx.Hash()] = entered
+		}
+		age := time.Since(entered)
+		if pool.config.QueueTTL > 0 && age > pool.config.QueueTTL {
+			pool.removeTx(tx.Hash(), true, true)
+			evicted++
+			continue
+		}
+		if age > oldest {
+			oldest = age
+		}
+	}
+	queuedTTLMeter.Mark(int64(evicted))
+	return oldest
+}
+
 // Add enqueues a batch of transactions into the pool if they are valid. Depending
 // on the local flag, full pricing constraints will or will not be applied.
 //
 // If sync is set, the method will block until all internal maintenance related
 // to the add is finished. Only use this during tests for determinism!
 func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error {
+	return pool.addTxs(txs, local, sync, true)
+}
+
+// addTxs is the implementation of Add, with admit denoting whether the batch is
+// newly submitted and should count towards the churn of its senders, or is just
+// reloaded from the journal.
+func (pool *LegacyPool) addTxs(txs []*types.Transaction, local, sync, admit bool) []error {
 	// Do not treat as local if local transactions have been disabled
 	local = local && !pool.config.NoLocals
 
@@ -1018,6 +1384,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	var (
 		errs = make([]error, len(txs))
 		news = make([]*types.Transaction, 0, len(txs))
+		shed = !local && pool.breaker.tripped()
 	)
 	for i, tx := range txs {
 		// If the transaction is known, pre-set the error slot
@@ -1026,6 +1393,13 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 			knownTxMeter.Mark(1)
 			continue
 		}
+		// If the pool is overloaded, reject remote transactions before spending
+		// any more resources on them
+		if shed {
+			errs[i] = txpool.ErrOverloaded
+			shedMeter.Mark(1)
+			continue
+		}
 		// Exclude transactions with basic errors, e.g invalid signatures and
 		// insufficient intrinsic gas as soon as possible and cache senders
 		// in transactions before obtaining lock
@@ -1043,8 +1417,10 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	}
 
 	// Process all the new transaction and merge any errors into the original slice
+	start := time.Now()
 	pool.mu.Lock()
-	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
+	pool.breaker.observe(time.Since(start))
+	newErrs, dirtyAddrs := pool.addTxsLocked(news, local, admit)
 	pool.mu.Unlock()
 
 	var nilSlot = 0
@@ -1063,14 +1439,23 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	return errs
 }
 
-// addTxsLocked attempts to queue a batch of transactions if they are valid.
+// addTxsLocked attempts to queue a batch of transactions if they are valid. If
+// admit is set, the accepted transactions count towards the churn of their
+// senders, which reinjected and reloaded ones must not.
 // The transaction pool lock must be held.
-func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error, *accountSet) {
+func (pool *LegacyPool) addTxsLocked(txs []*types.Transaction, local, admit bool) ([]error, *accountSet) {
 	dirty := newAccountSet(pool.signer)
 	errs := make([]error, len(txs))
 	for i, tx := range txs {
 		replaced, err := pool.add(tx, local)
 		errs[i] = err
+		if err == nil && admit {
+			from, _ := types.Sender(pool.signer, tx) // already validated
+			if pool.churn[from] == nil {
+				pool.churn[from] = &churn{since: time.Now()}
+			}
+			pool.churn[from].added++
+		}
 		if err == nil && !replaced {
 			dirty.addTx(tx)
 		}
@@ -1135,6 +1520,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 		return 0
 	}
 	addr, _ := types.Sender(pool.signer, tx) // already validated during insertion
+	delete(pool.entered, hash)
 
 	// If after deletion there are no more transactions belonging to this account,
 	// relinquish the address reservation. It's a bit convoluted do this, via a
@@ -1301,16 +1687,24 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	defer close(done)
 
 	var promoteAddrs []common.Address
-	if dirtyAccounts != nil && reset == nil {
+	if dirtyAccounts != nil {
 		// Only dirty accounts need to be promoted, unless we're resetting.
-		// For resets, all addresses in the tx queue will be promoted and
-		// the flatten operation can be avoided.
+		// For full resets, all addresses in the tx queue will be promoted.
 		promoteAddrs = dirtyAccounts.flatten()
 	}
+	// Retrieve everything needed to reset from the old head to the new before
+	// grabbing the lock, so chain and database access doesn't stall the pool
+	var plan *resetPlan
+	if reset != nil {
+		plan = pool.prepareReset(reset.oldHead, reset.newHead)
+	}
+	start := time.Now()
 	pool.mu.Lock()
+	pool.breaker.observe(time.Since(start))
+	var touched *accountSet
 	if reset != nil {
 		// Reset from the old head to the new, rescheduling any reorged transactions
-		pool.reset(reset.oldHead, reset.newHead)
+		touched = pool.applyReset(plan)
 
 		// Nonces were reset, discard any events that became stale
 		for addr := range events {
@@ -1319,10 +1713,20 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 				delete(events, addr)
 			}
 		}
-		// Reset needs promote for all addresses
-		promoteAddrs = make([]common.Address, 0, len(pool.queue))
-		for addr := range pool.queue {
-			promoteAddrs = append(promoteAddrs, addr)
+		if touched == nil {
+			// Full reset needs promote for all addresses
+			promoteAddrs = make([]common.Address, 0, len(pool.queue))
+			for addr := range pool.queue {
+				promoteAddrs = append(promoteAddrs, addr)
+			}
+		} else {
+			// Incremental reset only needs to promote the accounts touched by
+			// the new head on top of the dirty ones
+			for addr := range touched.accounts {
+				if _, ok := pool.queue[addr]; ok && (dirtyAccounts == nil || !dirtyAccounts.contains(addr)) {
+					promoteAddrs = append(promoteAddrs, addr)
+				}
+			}
 		}
 	}
 	// Check for pending transactions for every account that sent new ones
@@ -1332,7 +1736,11 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// remove any transaction that has been included in the block or was invalidated
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
-		pool.demoteUnexecutables()
+		if touched == nil {
+			pool.demoteUnexecutables()
+		} else {
+			pool.demoteAccounts(touched)
+		}
 		if reset.newHead != nil {
 			if pool.chainconfig.IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
 				pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead, reset.newHead.Time+1)
@@ -1376,11 +1784,25 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 
 // reset retrieves the current state of the blockchain and ensures the content
 // of the transaction pool is valid with regard to the chain state.
+//
+// Note, this method assumes the pool lock is held!
 func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
+	pool.applyReset(pool.prepareReset(oldHead, newHead))
+}
+
+// prepareReset retrieves everything needed to move the pool from the old head to
+// the new one: the new state, the transactions to reinject after a reorg and the
+// accounts modified by the new head. It only accesses the chain and may be run
+// without holding the pool lock. A nil plan means the reset must be skipped.
+func (pool *LegacyPool) prepareReset(oldHead, newHead *types.Header) *resetPlan {
+	defer func(start time.Time) {
+		resetPrepareTimer.UpdateSince(start)
+	}(time.Now())
+
 	// If we're reorging an old state, reinject all dropped transactions
 	var reinject types.Transactions
 
-	if oldHead != nil && oldHead.Hash() != newHead.ParentHash {
+	if oldHead != nil && newHead != nil && oldHead.Hash() != newHead.ParentHash {
 		// If the reorg is too deep, avoid doing it (will happen during fast sync)
 		oldNum := oldHead.Number.Uint64()
 		newNum := newHead.Number.Uint64()
@@ -1402,7 +1824,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 					// If we reorged to a same or higher number, then it's not a case of setHead
 					log.Warn("Transaction pool reset with missing old head",
 						"old", oldHead.Hash(), "oldnum", oldNum, "new", newHead.Hash(), "newnum", newNum)
-					return
+					return nil
 				}
 				// If the reorg ended up on a lower number, it's indicative of setHead being the cause
 				log.Debug("Skipping transaction reset caused by setHead",
@@ -1415,33 +1837,33 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 					// reorg caused by sync-reversion or explicit sethead back to an
 					// earlier block.
 					log.Warn("Transaction pool reset with missing new head", "number", newHead.Number, "hash", newHead.Hash())
-					return
+					return nil
 				}
 				var discarded, included types.Transactions
 				for rem.NumberU64() > add.NumberU64() {
 					discarded = append(discarded, rem.Transactions()...)
 					if rem = pool.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
 						log.Error("Unrooted old chain seen by tx pool", "block", oldHead.Number, "hash", oldHead.Hash())
-						return
+						return nil
 					}
 				}
 				for add.NumberU64() > rem.NumberU64() {
 					included = append(included, add.Transactions()...)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
-						return
+						return nil
 					}
 				}
 				for rem.Hash() != add.Hash() {
 					discarded = append(discarded, rem.Transactions()...)
 					if rem = pool.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
 						log.Error("Unrooted old chain seen by tx pool", "block", oldHead.Number, "hash", oldHead.Hash())
-						return
+						return nil
 					}
 					included = append(included, add.Transactions()...)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
-						return
+						return nil
 					}
 				}
 				lost := make([]*types.Transaction, 0, len(discarded))
@@ -1461,22 +1883,92 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	statedb, err := pool.chain.StateAt(newHead.Root)
 	if err != nil {
 		log.Error("Failed to reset txpool state", "err", err)
-		return
+		return nil
+	}
+	plan := &resetPlan{
+		oldHead:  oldHead,
+		newHead:  newHead,
+		state:    statedb,
+		reinject: reinject,
 	}
-	pool.currentHead.Store(newHead)
-	pool.currentState = statedb
-	pool.pendingNonces = newNoncer(statedb)
-
 	if costFn := types.NewL1CostFunc(pool.chainconfig, statedb); costFn != nil {
-		pool.l1CostFn = func(rollupCostData types.RollupCostData) *big.Int {
+		plan.l1CostFn = func(rollupCostData types.RollupCostData) *big.Int {
 			return costFn(rollupCostData, newHead.Time)
 		}
+		plan.l1FeeParams = l1FeeParams{
+			statedb.GetState(types.L1BlockAddr, types.L1BaseFeeSlot),
+			statedb.GetState(types.L1BlockAddr, types.L1FeeScalarsSlot),
+			statedb.GetState(types.L1BlockAddr, types.OverheadSlot),
+			statedb.GetState(types.L1BlockAddr, types.ScalarSlot),
+			statedb.GetState(types.L1BlockAddr, types.L1BlobBaseFeeSlot),
+		}
 	}
+	// If the new head directly extends the old one, only the senders of its
+	// transactions could have had their nonces or balances reduced
+	if oldHead != nil && oldHead.Hash() == newHead.ParentHash {
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			touched := newAccountSet(pool.signer)
+			for _, tx := range block.Transactions() {
+				from, err := types.Sender(pool.signer, tx)
+				if err != nil {
+					touched = nil
+					break
+				}
+				touched.add(from)
+			}
+			plan.touched = touched
+		}
+	}
+	// Recover the senders of the reinjected transactions while still lock free
+	core.SenderCacher.Recover(pool.signer, reinject)
+	return plan
+}
 
+// applyReset switches the pool over to the state of a prepared reset plan and
+// reinjects any transactions discarded by a reorg. If the transactions of only
+// a subset of the accounts need revalidation, that set is returned. A nil set
+// means all accounts need to be revalidated.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) applyReset(plan *resetPlan) *accountSet {
+	if plan == nil {
+		return nil
+	}
+	touched := plan.touched
+	if touched != nil {
+		// The incremental revalidation of the accounts touched by the new head
+		// is only sound if everything else affecting all accounts is unchanged
+		oldHead, newHead := pool.currentHead.Load(), plan.newHead
+		switch {
+		case oldHead == nil || oldHead.Hash() != plan.oldHead.Hash():
+			touched = nil // Pool is not at the state of the old head
+		case newHead.GasLimit < oldHead.GasLimit:
+			touched = nil // Gas limit decrease can invalidate any transaction
+		case pool.l1FeeParams != plan.l1FeeParams:
+			touched = nil // L1 cost change can make any transaction unaffordable
+		case pool.chainconfig.IsOptimismEcotone(oldHead.Time) != pool.chainconfig.IsOptimismEcotone(newHead.Time),
+			pool.chainconfig.IsOptimismFjord(oldHead.Time) != pool.chainconfig.IsOptimismFjord(newHead.Time):
+			touched = nil // L1 cost function changed across the fork
+		}
+	}
+	if touched == nil {
+		resetFullMeter.Mark(1)
+	} else {
+		resetIncrementalMeter.Mark(1)
+	}
+	pool.currentHead.Store(plan.newHead)
+	pool.currentState = plan.state
+	pool.pendingNonces = newNoncer(plan.state)
+	pool.l1FeeParams = plan.l1FeeParams
+	if plan.l1CostFn != nil {
+		pool.l1CostFn = plan.l1CostFn
+		pool.priced.SetL1CostFn(pool.l1CostFn)
+	}
 	// Inject any transactions discarded due to reorgs
-	log.Debug("Reinjecting stale transactions", "count", len(reinject))
-	core.SenderCacher.Recover(pool.signer, reinject)
-	pool.addTxsLocked(reinject, false)
+	log.Debug("Reinjecting stale transactions", "count", len(plan.reinject))
+	pool.addTxsLocked(plan.reinject, false, false)
+
+	return touched
 }
 
 // reduceBalanceByL1Cost returns the given balance, reduced by the L1Cost of the first transaction in list if applicable
@@ -1542,7 +2034,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 
 		// Drop all transactions over the allowed limit
 		var caps types.Transactions
-		if !pool.locals.contains(addr) {
+		if !pool.locals.contains(addr) && !pool.priority.contains(addr) {
 			caps = list.Cap(int(pool.config.AccountQueue))
 			for _, tx := range caps {
 				hash := tx.Hash()
@@ -1586,7 +2078,7 @@ func (pool *LegacyPool) truncatePending() {
 	spammers := prque.New[int64, common.Address](nil)
 	for addr, list := range pool.pending {
 		// Only evict transactions from high rollers
-		if !pool.locals.contains(addr) && uint64(list.Len()) > pool.config.AccountSlots {
+		if !pool.locals.contains(addr) && !pool.priority.contains(addr) && uint64(list.Len()) > pool.config.AccountSlots {
 			spammers.Push(addr, int64(list.Len()))
 		}
 	}
@@ -1712,55 +2204,72 @@ func (pool *LegacyPool) demoteUnexecutables() {
 	// Iterate over all accounts and demote any non-executable transactions
 	gasLimit := txpool.EffectiveGasLimit(pool.chainconfig, pool.currentHead.Load().GasLimit, pool.config.EffectiveGasCeil)
 	for addr, list := range pool.pending {
-		nonce := pool.currentState.GetNonce(addr)
+		pool.demoteAccount(addr, list, gasLimit)
+	}
+}
 
-		// Drop all transactions that are deemed too old (low nonce)
-		olds := list.Forward(nonce)
-		for _, tx := range olds {
-			hash := tx.Hash()
-			pool.all.Remove(hash)
-			log.Trace("Removed old pending transaction", "hash", hash)
-		}
-		balance := pool.currentState.GetBalance(addr)
-		balance = pool.reduceBalanceByL1Cost(list, balance)
-		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
-		drops, invalids := list.Filter(balance, gasLimit)
-		for _, tx := range drops {
-			hash := tx.Hash()
-			log.Trace("Removed unpayable pending transaction", "hash", hash)
-			pool.all.Remove(hash)
+// demoteAccounts is like demoteUnexecutables, but only revalidates the pending
+// transactions of the given accounts.
+func (pool *LegacyPool) demoteAccounts(accounts *accountSet) {
+	gasLimit := txpool.EffectiveGasLimit(pool.chainconfig, pool.currentHead.Load().GasLimit, pool.config.EffectiveGasCeil)
+	for addr := range accounts.accounts {
+		if list := pool.pending[addr]; list != nil {
+			pool.demoteAccount(addr, list, gasLimit)
 		}
-		pendingNofundsMeter.Mark(int64(len(drops)))
+	}
+}
+
+// demoteAccount removes the invalid and processed pending transactions of a
+// single account, moving any subsequent transactions back to the future queue.
+func (pool *LegacyPool) demoteAccount(addr common.Address, list *list, gasLimit uint64) {
+	nonce := pool.currentState.GetNonce(addr)
+
+	// Drop all transactions that are deemed too old (low nonce)
+	olds := list.Forward(nonce)
+	for _, tx := range olds {
+		hash := tx.Hash()
+		pool.all.Remove(hash)
+		log.Trace("Removed old pending transaction", "hash", hash)
+	}
+	balance := pool.currentState.GetBalance(addr)
+	balance = pool.reduceBalanceByL1Cost(list, balance)
+	// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
+	drops, invalids := list.Filter(balance, gasLimit)
+	for _, tx := range drops {
+		hash := tx.Hash()
+		log.Trace("Removed unpayable pending transaction", "hash", hash)
+		pool.all.Remove(hash)
+	}
+	pendingNofundsMeter.Mark(int64(len(drops)))
 
-		for _, tx := range invalids {
+	for _, tx := range invalids {
+		hash := tx.Hash()
+		log.Trace("Demoting pending transaction", "hash", hash)
+
+		// Internal shuffle shouldn't touch the lookup set.
+		pool.enqueueTx(hash, tx, false, false)
+	}
+	pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
+	if pool.locals.contains(addr) {
+		localGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
+	}
+	// If there's a gap in front, alert (should never happen) and postpone all transactions
+	if list.Len() > 0 && list.txs.Get(nonce) == nil {
+		gapped := list.Cap(0)
+		for _, tx := range gapped {
 			hash := tx.Hash()
-			log.Trace("Demoting pending transaction", "hash", hash)
+			log.Error("Demoting invalidated transaction", "hash", hash)
 
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false, false)
 		}
-		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
-		if pool.locals.contains(addr) {
-			localGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
-		}
-		// If there's a gap in front, alert (should never happen) and postpone all transactions
-		if list.Len() > 0 && list.txs.Get(nonce) == nil {
-			gapped := list.Cap(0)
-			for _, tx := range gapped {
-				hash := tx.Hash()
-				log.Error("Demoting invalidated transaction", "hash", hash)
-
-				// Internal shuffle shouldn't touch the lookup set.
-				pool.enqueueTx(hash, tx, false, false)
-			}
-			pendingGauge.Dec(int64(len(gapped)))
-		}
-		// Delete the entire pending entry if it became empty.
-		if list.Empty() {
-			delete(pool.pending, addr)
-			if _, ok := pool.queue[addr]; !ok {
-				pool.reserve(addr, false)
-			}
+		pendingGauge.Dec(int64(len(gapped)))
+	}
+	// Delete the entire pending entry if it became empty.
+	if list.Empty() {
+		delete(pool.pending, addr)
+		if _, ok := pool.queue[addr]; !ok {
+			pool.reserve(addr, false)
 		}
 	}
 }
@@ -98,6 +98,45 @@ func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent)
 	return bc.chainHeadFeed.Subscribe(ch)
 }
 
+// forkTestBlockChain is a testBlockChain that serves an explicitly built tree of
+// blocks, allowing pool resets across real chain extensions and reorgs.
+type forkTestBlockChain struct {
+	*testBlockChain
+	genesis *types.Block
+	blocks  map[common.Hash]*types.Block
+}
+
+func newForkTestBlockChain(bc *testBlockChain) *forkTestBlockChain {
+	genesis := types.NewBlock(bc.CurrentBlock(), nil, nil, trie.NewStackTrie(nil))
+	return &forkTestBlockChain{
+		testBlockChain: bc,
+		genesis:        genesis,
+		blocks:         map[common.Hash]*types.Block{genesis.Hash(): genesis},
+	}
+}
+
+func (bc *forkTestBlockChain) CurrentBlock() *types.Header {
+	return bc.genesis.Header()
+}
+
+// extend creates a child block of parent containing the given transactions.
+func (bc *forkTestBlockChain) extend(parent *types.Header, gasLimit uint64, extra byte, txs ...*types.Transaction) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   gasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Extra:      []byte{extra},
+	}
+	block := types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+	bc.blocks[block.Hash()] = block
+	return block.Header()
+}
+
+func (bc *forkTestBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.blocks[hash]
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) *types.Transaction {
 	return pricedTransaction(nonce, gaslimit, big.NewInt(1), key)
 }
@@ -356,6 +395,57 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that registered admission hooks are consulted after the built-in checks
+// and that their verdict is propagated back to the caller.
+func TestAdmissionValidators(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	var (
+		banned, _ = crypto.GenerateKey()
+		errBanned = errors.New("sender banned")
+		calls     int
+	)
+	pool.RegisterValidator(txpool.TxValidatorFunc(func(tx *types.Transaction, from common.Address, state *state.StateDB) error {
+		calls++
+		if from == crypto.PubkeyToAddress(banned.PublicKey) {
+			return errBanned
+		}
+		return nil
+	}))
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(banned.PublicKey), big.NewInt(1000000))
+
+	// Transactions failing the built-in checks should never reach the hook
+	if err := pool.addRemote(transaction(0, 100, key)); !errors.Is(err, core.ErrIntrinsicGas) {
+		t.Fatalf("want %v have %v", core.ErrIntrinsicGas, err)
+	}
+	if calls != 0 {
+		t.Fatalf("validator invoked for intrinsically invalid tx: %d calls", calls)
+	}
+	// Valid transactions should be accepted or rejected based on the hook
+	if err := pool.addRemoteSync(transaction(0, 100000, key)); err != nil {
+		t.Fatalf("failed to add allowed transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(transaction(0, 100000, banned)); !errors.Is(err, errBanned) {
+		t.Fatalf("want %v have %v", errBanned, err)
+	}
+	if err := pool.addLocal(transaction(1, 100000, banned)); !errors.Is(err, errBanned) {
+		t.Fatalf("local: want %v have %v", errBanned, err)
+	}
+	if calls != 3 {
+		t.Fatalf("validator invocation mismatch: have %d, want %d", calls, 3)
+	}
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want %d/%d", pending, queued, 1, 0)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -489,6 +579,132 @@ func TestChainFork(t *testing.T) {
 	}
 }
 
+// Tests that resets to a head directly extending the previous one only revisit
+// the accounts touched by the new block, while anything else falls back to a
+// full revalidation of the pool.
+func TestResetIncremental(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newForkTestBlockChain(newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed)))
+
+	pool := New(testTxPoolConfig, blockchain)
+	pool.Init(testTxPoolConfig.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	addrA, addrB := crypto.PubkeyToAddress(keyA.PublicKey), crypto.PubkeyToAddress(keyB.PublicKey)
+	testAddBalance(pool, addrA, big.NewInt(1000000000))
+	testAddBalance(pool, addrB, big.NewInt(1000000000))
+
+	var txsA types.Transactions
+	for i := 0; i < 3; i++ {
+		txsA = append(txsA, transaction(uint64(i), 100000, keyA))
+		if err := pool.addRemoteSync(txsA[i]); err != nil {
+			t.Fatalf("failed to add transaction %d of A: %v", i, err)
+		}
+		if err := pool.addRemoteSync(transaction(uint64(i), 100000, keyB)); err != nil {
+			t.Fatalf("failed to add transaction %d of B: %v", i, err)
+		}
+	}
+	// Include the first transaction of A in a new block and drain B's funds behind
+	// the pool's back. Since B isn't touched by the block, it must not be revisited.
+	genesis := blockchain.CurrentBlock()
+	head := blockchain.extend(genesis, genesis.GasLimit, 0, txsA[0])
+
+	testSetNonce(pool, addrA, 1)
+	pool.mu.Lock()
+	pool.currentState.SetBalance(addrB, new(uint256.Int), tracing.BalanceChangeUnspecified)
+	pool.mu.Unlock()
+
+	<-pool.requestReset(genesis, head)
+	if have := pool.pending[addrA].Len(); have != 2 {
+		t.Errorf("pending transactions of A mismatch: have %d, want %d", have, 2)
+	}
+	if have := pool.pending[addrB].Len(); have != 3 {
+		t.Errorf("pending transactions of B mismatch: have %d, want %d", have, 3)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	// Shrink the gas limit in the next block, forcing a full revalidation
+	next := blockchain.extend(head, head.GasLimit-1, 0)
+
+	<-pool.requestReset(head, next)
+	if have := pool.pending[addrA].Len(); have != 2 {
+		t.Errorf("pending transactions of A mismatch: have %d, want %d", have, 2)
+	}
+	if list := pool.pending[addrB]; list != nil {
+		t.Errorf("pending transactions of B not dropped: have %d, want %d", list.Len(), 0)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that resets across reorgs and rapidly succeeding heads leave the pool
+// consistent with the final head.
+func TestResetReorg(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newForkTestBlockChain(newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed)))
+
+	pool := New(testTxPoolConfig, blockchain)
+	pool.Init(testTxPoolConfig.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	var txs types.Transactions
+	for i := 0; i < 3; i++ {
+		txs = append(txs, transaction(uint64(i), 100000, key))
+	}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	checkPending := func(stage string, want int) {
+		t.Helper()
+
+		pending, queued := pool.Stats()
+		if pending != want || queued != 0 {
+			t.Fatalf("%s: pool stats mismatch: have %d pending %d queued, want %d pending 0 queued", stage, pending, queued, want)
+		}
+		if err := validatePoolInternals(pool); err != nil {
+			t.Fatalf("%s: pool internal state corrupted: %v", stage, err)
+		}
+	}
+	// Include the first transaction, then reorg it out to an empty sibling
+	genesis := blockchain.CurrentBlock()
+	head := blockchain.extend(genesis, genesis.GasLimit, 0, txs[0])
+	testSetNonce(pool, addr, 1)
+	<-pool.requestReset(genesis, head)
+	checkPending("extension", 2)
+
+	sibling := blockchain.extend(genesis, genesis.GasLimit, 1)
+	testSetNonce(pool, addr, 0)
+	<-pool.requestReset(head, sibling)
+	checkPending("reorg", 3)
+
+	// Include the first two transactions across two heads, announced in quick
+	// succession without waiting for the resets in between
+	first := blockchain.extend(sibling, sibling.GasLimit, 0, txs[0])
+	second := blockchain.extend(first, first.GasLimit, 0, txs[1])
+	testSetNonce(pool, addr, 2)
+	pool.requestReset(sibling, first)
+	<-pool.requestReset(first, second)
+	checkPending("rapid heads", 1)
+
+	if tx := pool.pending[addr].txs.Get(2); tx == nil || tx.Hash() != txs[2].Hash() {
+		t.Fatalf("remaining pending transaction mismatch")
+	}
+}
+
 func TestDoubleNonce(t *testing.T) {
 	t.Parallel()
 
@@ -1141,6 +1357,118 @@ func testQueueTimeLimiting(t *testing.T, nolocals bool) {
 	}
 }
 
+// Tests that individual non-executable transactions are evicted once they exceed
+// the queue TTL, even if their account is still active.
+func TestQueueTTL(t *testing.T) {
+	// Reduce the eviction interval to a testable amount
+	defer func(old time.Duration) { evictionInterval = old }(evictionInterval)
+	evictionInterval = time.Millisecond * 100
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.QueueTTL = time.Second
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	local, _ := crypto.GenerateKey()
+	remote, _ := crypto.GenerateKey()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(local.PublicKey), big.NewInt(1000000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(remote.PublicKey), big.NewInt(1000000000))
+
+	// Queue up a gapped transaction from both accounts, then keep the remote
+	// account alive with a newer one before the TTL is reached
+	if err := pool.addLocal(pricedTransaction(1, 100000, big.NewInt(1), local)); err != nil {
+		t.Fatalf("failed to add local transaction: %v", err)
+	}
+	stale := pricedTransaction(1, 100000, big.NewInt(1), remote)
+	if err := pool.addRemote(stale); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+	time.Sleep(6 * evictionInterval)
+
+	fresh := pricedTransaction(2, 100000, big.NewInt(1), remote)
+	if err := pool.addRemote(fresh); err != nil {
+		t.Fatalf("failed to add remote transaction: %v", err)
+	}
+	time.Sleep(7 * evictionInterval)
+
+	// The stale remote should be gone, the fresh remote and the local retained
+	if pool.all.Get(stale.Hash()) != nil {
+		t.Errorf("stale queued transaction not evicted")
+	}
+	if pool.all.Get(fresh.Hash()) == nil {
+		t.Errorf("fresh queued transaction evicted")
+	}
+	pending, queued := pool.Stats()
+	if pending != 0 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 0)
+	}
+	if queued != 2 {
+		t.Fatalf("queued transactions mismatched: have %d, want %d", queued, 2)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the queue TTL of transactions demoted from pending is measured from
+// their demotion, not from when they were first seen.
+func TestQueueTTLDemoted(t *testing.T) {
+	// Reduce the eviction interval to a testable amount
+	defer func(old time.Duration) { evictionInterval = old }(evictionInterval)
+	evictionInterval = time.Millisecond * 100
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.QueueTTL = time.Second
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(2000000))
+
+	// Add two executable transactions, the first one much more expensive
+	expensive := pricedTransaction(0, 100000, big.NewInt(10), key)
+	gapped := transaction(1, 100000, key)
+	for i, err := range pool.addRemotesSync([]*types.Transaction{expensive, gapped}) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	time.Sleep(12 * evictionInterval)
+
+	// Drop the balance below the first transaction's cost, demoting the second
+	testAddBalance(pool, account, big.NewInt(-1500000))
+	<-pool.requestReset(nil, nil)
+
+	if pending, queued := pool.Stats(); pending != 0 || queued != 1 {
+		t.Fatalf("pool stats mismatch after demotion: have %d/%d, want %d/%d", pending, queued, 0, 1)
+	}
+	// The demoted transaction was first seen more than a TTL ago, but only
+	// entered the queue now, so it should survive until a TTL passes from here
+	time.Sleep(5 * evictionInterval)
+	if pool.all.Get(gapped.Hash()) == nil {
+		t.Fatalf("demoted transaction evicted before its queue TTL")
+	}
+	time.Sleep(10 * evictionInterval)
+	if pool.all.Get(gapped.Hash()) != nil {
+		t.Fatalf("demoted transaction not evicted after its queue TTL")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that even if the transaction count belonging to a single account goes
 // above some threshold, as long as the transactions are executable, they are
 // accepted.
@@ -1366,6 +1694,217 @@ func TestPendingMinimumAllowance(t *testing.T) {
 	}
 }
 
+// Tests that priority accounts are exempt from both the per-account queue limit
+// and the pending fairness caps, while other accounts are still limited.
+func TestPriorityAccountLimiting(t *testing.T) {
+	t.Parallel()
+
+	// Create the pool to test the limit enforcement with
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	var (
+		prioKey, _   = crypto.GenerateKey()
+		remoteKey, _ = crypto.GenerateKey()
+		prioAddr     = crypto.PubkeyToAddress(prioKey.PublicKey)
+		remoteAddr   = crypto.PubkeyToAddress(remoteKey.PublicKey)
+	)
+	config := testTxPoolConfig
+	config.GlobalSlots = 1
+	config.Priority = []common.Address{prioAddr}
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	testAddBalance(pool, prioAddr, big.NewInt(1000000000))
+	testAddBalance(pool, remoteAddr, big.NewInt(1000000000))
+
+	// Queue up gapped transactions above the account queue limit
+	queued := int(config.AccountQueue) + 5
+	for i := 1; i <= queued; i++ {
+		if err := pool.addRemoteSync(transaction(uint64(i), 100000, prioKey)); err != nil {
+			t.Fatalf("priority tx %d: failed to add transaction: %v", i, err)
+		}
+		if err := pool.addRemoteSync(transaction(uint64(i), 100000, remoteKey)); err != nil {
+			t.Fatalf("remote tx %d: failed to add transaction: %v", i, err)
+		}
+	}
+	if have := pool.queue[prioAddr].Len(); have != queued {
+		t.Errorf("priority queue size mismatch: have %d, want %d", have, queued)
+	}
+	if have := pool.queue[remoteAddr].Len(); have != int(config.AccountQueue) {
+		t.Errorf("remote queue limit mismatch: have %d, want %d", have, config.AccountQueue)
+	}
+	// Fill the nonce gaps, promoting everything above the global pending limit
+	pool.addRemotesSync([]*types.Transaction{transaction(0, 100000, prioKey), transaction(0, 100000, remoteKey)})
+
+	if have := pool.pending[prioAddr].Len(); have != queued+1 {
+		t.Errorf("priority pending size mismatch: have %d, want %d", have, queued+1)
+	}
+	if have := pool.pending[remoteAddr].Len(); have != int(config.AccountSlots) {
+		t.Errorf("remote pending allowance mismatch: have %d, want %d", have, config.AccountSlots)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the top senders report ranks accounts by their pooled transaction
+// count and tracks their churn.
+func TestTopSenders(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Close()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	// Account 0 has two pending and one queued, account 1 two pending with a
+	// replacement, account 2 a single pending transaction
+	txs := []*types.Transaction{
+		transaction(0, 100000, keys[0]), transaction(1, 100000, keys[0]), transaction(5, 100000, keys[0]),
+		transaction(0, 100000, keys[1]), pricedTransaction(0, 100000, big.NewInt(2), keys[1]), transaction(1, 200000, keys[1]),
+		transaction(0, 100000, keys[2]),
+	}
+	for i, tx := range txs {
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	top := pool.TopSenders(2)
+	if len(top) != 2 {
+		t.Fatalf("top sender count mismatch: have %d, want %d", len(top), 2)
+	}
+	want := []SenderStats{
+		{Address: crypto.PubkeyToAddress(keys[0].PublicKey), Pending: 2, Queued: 1, Gas: 300000},
+		{Address: crypto.PubkeyToAddress(keys[1].PublicKey), Pending: 2, Queued: 0, Gas: 300000},
+	}
+	for i := range want {
+		if top[i].Address != want[i].Address || top[i].Pending != want[i].Pending || top[i].Queued != want[i].Queued || top[i].Gas != want[i].Gas {
+			t.Errorf("top sender %d mismatch: have %+v, want %+v", i, top[i], want[i])
+		}
+	}
+	if all := pool.TopSenders(0); len(all) != 3 {
+		t.Fatalf("all sender count mismatch: have %d, want %d", len(all), 3)
+	}
+	// Replacements count towards churn, which is averaged over at least a minute
+	churns := map[common.Address]float64{
+		crypto.PubkeyToAddress(keys[0].PublicKey): 3,
+		crypto.PubkeyToAddress(keys[1].PublicKey): 3,
+		crypto.PubkeyToAddress(keys[2].PublicKey): 1,
+	}
+	for _, stat := range pool.TopSenders(0) {
+		if want := churns[stat.Address]; stat.Churn != want {
+			t.Errorf("churn of %x mismatch: have %v, want %v", stat.Address, stat.Churn, want)
+		}
+	}
+	// Reinjected transactions (e.g. after a reorg) must not count towards churn
+	pool.mu.Lock()
+	pool.addTxsLocked([]*types.Transaction{transaction(1, 100000, keys[2])}, false, false)
+	pool.mu.Unlock()
+
+	for _, stat := range pool.TopSenders(0) {
+		if want := churns[stat.Address]; stat.Churn != want {
+			t.Errorf("churn of %x mismatch after reinjection: have %v, want %v", stat.Address, stat.Churn, want)
+		}
+	}
+}
+
+// Tests that cached static validation results don't leak into the checks which
+// depend on the current head.
+func TestValidationCache(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(params.Ether))
+
+	// Statically invalid transactions should be rejected repeatedly
+	tx := transaction(0, 100, key)
+	for i := 0; i < 2; i++ {
+		if err := pool.addRemoteSync(tx); !errors.Is(err, core.ErrIntrinsicGas) {
+			t.Fatalf("attempt %d: error mismatch: have %v, want %v", i, err, core.ErrIntrinsicGas)
+		}
+	}
+	// Transactions exceeding the block gas limit should be accepted once the
+	// limit is raised, even though their static checks are cached
+	tx = transaction(0, 20000000, key)
+	if err := pool.addRemoteSync(tx); !errors.Is(err, txpool.ErrGasLimit) {
+		t.Fatalf("error mismatch: have %v, want %v", err, txpool.ErrGasLimit)
+	}
+	// The block gas limit should still be reported before the cached checks
+	if err := pool.addRemoteSync(dynamicFeeTx(0, 20000000, big.NewInt(1), big.NewInt(2), key)); !errors.Is(err, txpool.ErrGasLimit) {
+		t.Fatalf("error order mismatch: have %v, want %v", err, txpool.ErrGasLimit)
+	}
+	pool.chain.(*testBlockChain).gasLimit.Store(30000000)
+	<-pool.requestReset(nil, nil)
+
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add transaction after gas limit increase: %v", err)
+	}
+}
+
+// Tests that shrinking the pool limits at runtime evicts the excess transactions
+// and that partial updates leave the omitted limits unchanged.
+func TestSetLimits(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Close()
+
+	// Create a number of test accounts with both pending and queued transactions
+	keys := make([]*ecdsa.PrivateKey, 2)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	txs := types.Transactions{}
+	for _, key := range keys {
+		for nonce := uint64(0); nonce < 10; nonce++ {
+			txs = append(txs, transaction(nonce, 100000, key))
+		}
+		for nonce := uint64(11); nonce < 21; nonce++ {
+			txs = append(txs, transaction(nonce, 100000, key))
+		}
+	}
+	pool.addRemotesSync(txs)
+
+	if pending, queued := pool.Stats(); pending != 20 || queued != 20 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want %d/%d", pending, queued, 20, 20)
+	}
+	// Shrink all the limits and ensure the pool is truncated accordingly
+	want := Limits{AccountSlots: 4, GlobalSlots: 8, AccountQueue: 5, GlobalQueue: 8}
+	if have := pool.SetLimits(want); have != want {
+		t.Fatalf("effective limits mismatch: have %+v, want %+v", have, want)
+	}
+	if pending, queued := pool.Stats(); pending != 8 || queued != 8 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want %d/%d", pending, queued, 8, 8)
+	}
+	for addr, list := range pool.pending {
+		if list.Len() != int(want.AccountSlots) {
+			t.Errorf("addr %x: pending transactions mismatch: have %d, want %d", addr, list.Len(), want.AccountSlots)
+		}
+	}
+	for addr, list := range pool.queue {
+		if list.Len() > int(want.AccountQueue) {
+			t.Errorf("addr %x: queued transactions above limit: have %d, limit %d", addr, list.Len(), want.AccountQueue)
+		}
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	// Update a single limit and ensure the rest are retained
+	want.GlobalQueue = 100
+	if have := pool.SetLimits(Limits{GlobalQueue: 100}); have != want {
+		t.Fatalf("effective limits mismatch: have %+v, want %+v", have, want)
+	}
+}
+
 // Tests that setting the transaction pool gas price to a higher value correctly
 // discards everything cheaper than that and moves any gapped transactions back
 // from the pending pool to the queue.
@@ -2342,6 +2881,118 @@ func TestReplacementDynamicFee(t *testing.T) {
 	}
 }
 
+// Tests that the pool enforces a configured replacement policy instead of the
+// default percentage price bump.
+func TestReplacementPolicy(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	// Require an absolute bump of 50 wei, which is way below 10% for high prices
+	// and way above it for low ones
+	config := testTxPoolConfig
+	config.PriceBumpMode = txpool.PriceBumpAbsolute
+	config.PriceBumpWei = 50
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000000))
+
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1000), key)); err != nil {
+		t.Fatalf("failed to add original pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 100001, big.NewInt(1049), key)); err != txpool.ErrReplaceUnderpriced {
+		t.Fatalf("replacement below absolute bump error mismatch: have %v, want %v", err, txpool.ErrReplaceUnderpriced)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1050), key)); err != nil {
+		t.Fatalf("failed to replace with absolute bump below percentage bump: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(10), key)); err != nil {
+		t.Fatalf("failed to add original cheap pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(20), key)); err != txpool.ErrReplaceUnderpriced {
+		t.Fatalf("replacement above percentage bump error mismatch: have %v, want %v", err, txpool.ErrReplaceUnderpriced)
+	}
+	// Swap in a custom policy rejecting everything and ensure it's consulted
+	pool.mu.Lock()
+	pool.config.PriceBumpPolicy = txpool.PriceBumpFunc(func(old, next *txpool.ReplacementFees) error {
+		return txpool.ErrReplaceUnderpriced
+	})
+	pool.mu.Unlock()
+
+	if err := pool.addRemoteSync(pricedTransaction(1, 100000, big.NewInt(1000), key)); err != txpool.ErrReplaceUnderpriced {
+		t.Fatalf("custom policy replacement error mismatch: have %v, want %v", err, txpool.ErrReplaceUnderpriced)
+	}
+	// Percentage thresholds overflowing 256 bits should not wrap around
+	var (
+		max  = new(uint256.Int).SetAllOne()
+		half = new(uint256.Int).Rsh(max, 1)
+		high = new(uint256.Int).Sub(max, uint256.NewInt(1))
+		next = &txpool.ReplacementFees{GasFeeCap: max, GasTipCap: max}
+	)
+	if err := txpool.PercentageBump(10).Replaceable(&txpool.ReplacementFees{GasFeeCap: high, GasTipCap: high}, next); !errors.Is(err, txpool.ErrReplaceUnderpriced) {
+		t.Fatalf("overflowing replacement error mismatch: have %v, want %v", err, txpool.ErrReplaceUnderpriced)
+	}
+	if err := txpool.PercentageBump(10).Replaceable(&txpool.ReplacementFees{GasFeeCap: half, GasTipCap: half}, next); err != nil {
+		t.Fatalf("failed to replace near the 256 bit limit: %v", err)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that an overloaded pool sheds remote transactions while still accepting
+// local ones, and that it only recovers once the load dropped sufficiently.
+func TestOverloadShedding(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	// Replace the breaker with one tripping on a fake heap size
+	var heap uint64
+	pool.breaker = newBreaker(0, 100)
+	pool.breaker.readHeap = func() uint64 { return heap }
+
+	resample := func(size uint64) {
+		heap = size
+		pool.breaker.lock.Lock()
+		pool.breaker.sampled = time.Time{}
+		pool.breaker.lock.Unlock()
+	}
+	if err := pool.addRemoteSync(transaction(0, 100000, key)); err != nil {
+		t.Fatalf("failed to add remote transaction below threshold: %v", err)
+	}
+	resample(101)
+	if err := pool.addRemoteSync(transaction(1, 100000, key)); !errors.Is(err, txpool.ErrOverloaded) {
+		t.Fatalf("remote transaction error mismatch: have %v, want %v", err, txpool.ErrOverloaded)
+	}
+	if err := pool.addLocal(transaction(1, 100000, key)); err != nil {
+		t.Fatalf("failed to add local transaction while overloaded: %v", err)
+	}
+	// Dropping just below the threshold should not reset the breaker yet
+	resample(95)
+	if err := pool.addRemoteSync(transaction(2, 100000, key)); !errors.Is(err, txpool.ErrOverloaded) {
+		t.Fatalf("remote transaction error mismatch: have %v, want %v", err, txpool.ErrOverloaded)
+	}
+	resample(80)
+	if err := pool.addRemoteSync(transaction(2, 100000, key)); err != nil {
+		t.Fatalf("failed to add remote transaction after recovery: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 3 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 3)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that local transactions are journaled to disk, but remote transactions
 // get discarded between restarts.
 func TestJournaling(t *testing.T)         { testJournaling(t, false, false) }
@@ -2527,6 +3178,67 @@ func TestStatusCheck(t *testing.T) {
 	}
 }
 
+// Tests that the content can be paged through in status, sender and nonce order,
+// resuming after a cursor and honouring the sender and transaction filters.
+func TestContentRange(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Close()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	var txs types.Transactions
+	for _, key := range keys {
+		txs = append(txs, pricedTransaction(0, 100000, big.NewInt(1), key))
+		txs = append(txs, pricedTransaction(1, 100000, big.NewInt(2), key))
+		txs = append(txs, pricedTransaction(5, 100000, big.NewInt(1), key))
+	}
+	pool.addRemotesSync(txs)
+
+	// Page through the entire content and ensure it's ordered and complete
+	var (
+		after *txpool.ContentCursor
+		seen  []txpool.ContentItem
+	)
+	for {
+		items := pool.ContentRange(after, nil, 2, nil)
+		if len(items) == 0 {
+			break
+		}
+		if len(items) > 2 {
+			t.Fatalf("page size exceeded: have %d, want at most %d", len(items), 2)
+		}
+		seen = append(seen, items...)
+		cursor := items[len(items)-1].Cursor()
+		after = &cursor
+	}
+	if len(seen) != len(txs) {
+		t.Fatalf("paged transaction count mismatch: have %d, want %d", len(seen), len(txs))
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i-1].Cursor().Cmp(seen[i].Cursor()) >= 0 {
+			t.Fatalf("content out of order at %d: %v after %v", i, seen[i].Cursor(), seen[i-1].Cursor())
+		}
+	}
+	for i, item := range seen {
+		if queued := item.Tx.Nonce() == 5; item.Queued != queued {
+			t.Errorf("item %d: queued mismatch: have %v, want %v", i, item.Queued, queued)
+		}
+	}
+	// Ensure the sender and transaction filters are applied
+	sender := crypto.PubkeyToAddress(keys[1].PublicKey)
+	items := pool.ContentRange(nil, &sender, 10, func(tx *types.Transaction) bool {
+		return tx.GasPrice().Cmp(big.NewInt(2)) >= 0
+	})
+	if len(items) != 1 || items[0].Sender != sender || items[0].Tx.Nonce() != 1 {
+		t.Fatalf("filtered content mismatch: %v", items)
+	}
+}
+
 // Test the transaction slots consumption is computed correctly
 func TestSlotCount(t *testing.T) {
 	t.Parallel()
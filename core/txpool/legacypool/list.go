@@ -310,27 +310,11 @@ func (l *list) Contains(nonce uint64) bool {
 //
 // If the new transaction is accepted into the list, the lists' cost and gas
 // thresholds are also potentially updated.
-func (l *list) Add(tx *types.Transaction, priceBump uint64, l1CostFn txpool.L1CostFunc) (bool, *types.Transaction) {
+func (l *list) Add(tx *types.Transaction, policy txpool.PriceBumpPolicy, l1CostFn txpool.L1CostFunc) (bool, *types.Transaction) {
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
-		if old.GasFeeCapCmp(tx) >= 0 || old.GasTipCapCmp(tx) >= 0 {
-			return false, nil
-		}
-		// thresholdFeeCap = oldFC  * (100 + priceBump) / 100
-		a := big.NewInt(100 + int64(priceBump))
-		aFeeCap := new(big.Int).Mul(a, old.GasFeeCap())
-		aTip := a.Mul(a, old.GasTipCap())
-
-		// thresholdTip    = oldTip * (100 + priceBump) / 100
-		b := big.NewInt(100)
-		thresholdFeeCap := aFeeCap.Div(aFeeCap, b)
-		thresholdTip := aTip.Div(aTip, b)
-
-		// We have to ensure that both the new fee cap and tip are higher than the
-		// old ones as well as checking the percentage threshold to ensure that
-		// this is accurate for low (Wei-level) gas price replacements.
-		if tx.GasFeeCapIntCmp(thresholdFeeCap) < 0 || tx.GasTipCapIntCmp(thresholdTip) < 0 {
+		if policy.Replaceable(txpool.NewReplacementFees(old, l1CostFn), txpool.NewReplacementFees(tx, l1CostFn)) != nil {
 			return false, nil
 		}
 		// Old is being replaced, subtract old cost
@@ -489,15 +473,23 @@ func (l *list) subTotalCost(txs []*types.Transaction) {
 // then the heap is sorted based on the effective tip based on the given base fee.
 // If baseFee is nil then the sorting is based on gasFeeCap.
 type priceHeap struct {
-	baseFee *big.Int // heap should always be re-sorted after baseFee is changed
-	list    []*types.Transaction
+	baseFee  *big.Int             // heap should always be re-sorted after baseFee is changed
+	scoring  txpool.ScoringPolicy // ranking of transactions at baseFee, nil to compare effective tips
+	l1CostFn txpool.L1CostFunc    // rollup data cost fed into the scoring, nil if not a rollup
+	list     []*types.Transaction
+	scores   []*uint256.Int // scores of the transactions in list, only tracked if scoring is set
 }
 
-func (h *priceHeap) Len() int      { return len(h.list) }
-func (h *priceHeap) Swap(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] }
+func (h *priceHeap) Len() int { return len(h.list) }
+func (h *priceHeap) Swap(i, j int) {
+	h.list[i], h.list[j] = h.list[j], h.list[i]
+	if h.scoring != nil {
+		h.scores[i], h.scores[j] = h.scores[j], h.scores[i]
+	}
+}
 
 func (h *priceHeap) Less(i, j int) bool {
-	switch h.cmp(h.list[i], h.list[j]) {
+	switch h.cmp(h.list[i], h.list[j], h.scoreAt(i), h.scoreAt(j)) {
 	case -1:
 		return true
 	case 1:
@@ -507,10 +499,16 @@ func (h *priceHeap) Less(i, j int) bool {
 	}
 }
 
-func (h *priceHeap) cmp(a, b *types.Transaction) int {
+// cmp compares two transactions, using their scores if the heap is scored.
+func (h *priceHeap) cmp(a, b *types.Transaction, scoreA, scoreB *uint256.Int) int {
 	if h.baseFee != nil {
-		// Compare effective tips if baseFee is specified
-		if c := a.EffectiveGasTipCmp(b, h.baseFee); c != 0 {
+		if h.scoring != nil {
+			// Compare scores if a scoring policy is specified
+			if c := scoreA.Cmp(scoreB); c != 0 {
+				return c
+			}
+		} else if c := a.EffectiveGasTipCmp(b, h.baseFee); c != 0 {
+			// Compare effective tips if baseFee is specified
 			return c
 		}
 	}
@@ -522,9 +520,47 @@ func (h *priceHeap) cmp(a, b *types.Transaction) int {
 	return a.GasTipCapCmp(b)
 }
 
+// score calculates the score of a transaction at the current base fee and L1
+// cost, or nil if the heap is not scored.
+func (h *priceHeap) score(tx *types.Transaction) *uint256.Int {
+	if h.scoring == nil {
+		return nil
+	}
+	var baseFee *uint256.Int
+	if h.baseFee != nil {
+		baseFee = uint256.MustFromBig(h.baseFee)
+	}
+	return h.scoring.Score(txpool.NewScoringFees(tx, baseFee, h.l1CostFn))
+}
+
+// scoreAt returns the cached score of the i'th transaction, or nil if the heap
+// is not scored.
+func (h *priceHeap) scoreAt(i int) *uint256.Int {
+	if h.scoring == nil {
+		return nil
+	}
+	return h.scores[i]
+}
+
+// rescore recalculates the cached scores of all transactions. It must be called
+// whenever the list is replaced or the pricing parameters change, before the
+// heap is reinitialized.
+func (h *priceHeap) rescore() {
+	if h.scoring == nil {
+		return
+	}
+	h.scores = make([]*uint256.Int, len(h.list))
+	for i, tx := range h.list {
+		h.scores[i] = h.score(tx)
+	}
+}
+
 func (h *priceHeap) Push(x interface{}) {
 	tx := x.(*types.Transaction)
 	h.list = append(h.list, tx)
+	if h.scoring != nil {
+		h.scores = append(h.scores, h.score(tx))
+	}
 }
 
 func (h *priceHeap) Pop() interface{} {
@@ -533,6 +569,10 @@ func (h *priceHeap) Pop() interface{} {
 	x := old[n-1]
 	old[n-1] = nil
 	h.list = old[0 : n-1]
+	if h.scoring != nil {
+		h.scores[n-1] = nil
+		h.scores = h.scores[0 : n-1]
+	}
 	return x
 }
 
@@ -562,10 +602,12 @@ const (
 	floatingRatio = 1
 )
 
-// newPricedList creates a new price-sorted transaction heap.
-func newPricedList(all *lookup) *pricedList {
+// newPricedList creates a new price-sorted transaction heap. If a scoring policy
+// is given, it ranks the urgent heap instead of the effective tips.
+func newPricedList(all *lookup, scoring txpool.ScoringPolicy) *pricedList {
 	return &pricedList{
-		all: all,
+		all:    all,
+		urgent: priceHeap{scoring: scoring},
 	}
 }
 
@@ -620,7 +662,7 @@ func (l *pricedList) underpricedFor(h *priceHeap, tx *types.Transaction) bool {
 	}
 	// If the remote transaction is even cheaper than the
 	// cheapest one tracked locally, reject it.
-	return h.cmp(h.list[0], tx) >= 0
+	return h.cmp(h.list[0], tx, h.scoreAt(0), h.score(tx)) >= 0
 }
 
 // Discard finds a number of most underpriced transactions, removes them from the
@@ -677,6 +719,7 @@ func (l *pricedList) Reheap() {
 		l.urgent.list = append(l.urgent.list, tx)
 		return true
 	}, false, true) // Only iterate remotes
+	l.urgent.rescore()
 	heap.Init(&l.urgent)
 
 	// balance out the two heaps by moving the worse half of transactions into the
@@ -693,6 +736,12 @@ func (l *pricedList) Reheap() {
 	reheapTimer.Update(time.Since(start))
 }
 
+// SetL1CostFn updates the rollup data cost function used for scoring. It does not
+// re-heap, as it's always followed by a SetBaseFee when processing a new block.
+func (l *pricedList) SetL1CostFn(l1CostFn txpool.L1CostFunc) {
+	l.urgent.l1CostFn = l1CostFn
+}
+
 // SetBaseFee updates the base fee and triggers a re-heap. Note that Removed is not
 // necessary to call right before SetBaseFee when processing a new block.
 func (l *pricedList) SetBaseFee(baseFee *big.Int) {
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/holiman/uint256"
@@ -40,7 +41,7 @@ func TestStrictListAdd(t *testing.T) {
 	// Insert the transactions in a random order
 	list := newList(true)
 	for _, v := range rand.Perm(len(txs)) {
-		list.Add(txs[v], DefaultConfig.PriceBump, nil)
+		list.Add(txs[v], txpool.PercentageBump(DefaultConfig.PriceBump), nil)
 	}
 	// Verify internal state
 	if len(list.txs.items) != len(txs) {
@@ -64,7 +65,35 @@ func TestListAddVeryExpensive(t *testing.T) {
 		gaslimit := uint64(i)
 		tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, value, gaslimit, gasprice, nil), types.HomesteadSigner{}, key)
 		t.Logf("cost: %x bitlen: %d\n", tx.Cost(), tx.Cost().BitLen())
-		list.Add(tx, DefaultConfig.PriceBump, nil)
+		list.Add(tx, txpool.PercentageBump(DefaultConfig.PriceBump), nil)
+	}
+}
+
+// Tests that a scoring priced list evicts transactions by their tip net of the
+// L1 data cost, rather than by their raw tip.
+func TestPricedListNetTipEviction(t *testing.T) {
+	var (
+		all     = newLookup()
+		priced  = newPricedList(all, txpool.NetTipScoring())
+		key1, _ = crypto.GenerateKey()
+		key2, _ = crypto.GenerateKey()
+		cheap   = pricedTransaction(0, 100000, big.NewInt(5), key1)
+		bloated = pricedDataTransaction(0, 100000, big.NewInt(10), key2, 1000)
+	)
+	// Charge 1000 wei per non-zero byte, amortizing to a wei per gas for every
+	// hundred of them
+	priced.SetL1CostFn(func(rollupCostData types.RollupCostData) *big.Int {
+		return new(big.Int).SetUint64(rollupCostData.Ones * 1000)
+	})
+	for _, tx := range []*types.Transaction{cheap, bloated} {
+		all.Add(tx, false)
+		priced.Put(tx, false)
+	}
+	priced.SetBaseFee(common.Big0)
+
+	drop, ok := priced.Discard(1, false)
+	if !ok || len(drop) != 1 || drop[0] != bloated {
+		t.Fatalf("evicted transactions mismatch: have %v, want %x", drop, bloated.Hash())
 	}
 }
 
@@ -82,7 +111,7 @@ func BenchmarkListAdd(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		list := newList(true)
 		for _, v := range rand.Perm(len(txs)) {
-			list.Add(txs[v], DefaultConfig.PriceBump, nil)
+			list.Add(txs[v], txpool.PercentageBump(DefaultConfig.PriceBump), nil)
 			list.Filter(priceLimit, DefaultConfig.PriceBump)
 		}
 	}
@@ -102,7 +131,7 @@ func BenchmarkListCapOneTx(b *testing.B) {
 		list := newList(true)
 		// Insert the transactions in a random order
 		for _, v := range rand.Perm(len(txs)) {
-			list.Add(txs[v], DefaultConfig.PriceBump, nil)
+			list.Add(txs[v], txpool.PercentageBump(DefaultConfig.PriceBump), nil)
 		}
 		b.StartTimer()
 		list.Cap(list.Len() - 1)
@@ -0,0 +1,500 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package locals implements tracking of locally submitted transactions until
+// they are included in the chain.
+package locals
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	trackedGauge     = metrics.NewRegisteredGauge("txpool/tracker/pending", nil)
+	resubmitMeter    = metrics.NewRegisteredMeter("txpool/tracker/resubmit", nil)
+	rebroadcastMeter = metrics.NewRegisteredMeter("txpool/tracker/rebroadcast", nil)
+	includedMeter    = metrics.NewRegisteredMeter("txpool/tracker/included", nil)
+	replacedMeter    = metrics.NewRegisteredMeter("txpool/tracker/replaced", nil)
+	unknownMeter     = metrics.NewRegisteredMeter("txpool/tracker/unknown", nil)
+)
+
+// Chain defines the minimal set of methods needed to back a transaction tracker
+// with a chain. Exists to allow mocking the live chain out of tests.
+type Chain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// CurrentBlock returns the current head of the chain.
+	CurrentBlock() *types.Header
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+
+	// GetTransactionLookup retrieves the inclusion position of a transaction.
+	GetTransactionLookup(hash common.Hash) (*rawdb.LegacyTxLookupEntry, *types.Transaction, error)
+}
+
+// Pool defines the methods of the transaction pool the tracker resubmits its
+// transactions into.
+type Pool interface {
+	// Has returns an indicator whether the pool has a transaction cached.
+	Has(hash common.Hash) bool
+
+	// Add enqueues a batch of transactions into the pool if they are valid.
+	Add(txs []*types.Transaction, local bool, sync bool) []error
+}
+
+// Config are the configuration parameters of the local transaction tracker.
+type Config struct {
+	Journal    string        // Journal of tracked transactions to survive node restarts (empty = disabled)
+	Recheck    time.Duration // Interval to check for inclusions and due rebroadcasts
+	Backoff    time.Duration // Initial delay before a transaction is rebroadcast
+	MaxBackoff time.Duration // Maximum delay between two rebroadcasts of a transaction
+	Retention  time.Duration // Time the outcome of a finished transaction stays queryable
+}
+
+// DefaultConfig contains the default configurations for the transaction tracker.
+var DefaultConfig = Config{
+	Journal:    "tracked_transactions.rlp",
+	Recheck:    10 * time.Second,
+	Backoff:    time.Minute,
+	MaxBackoff: time.Hour,
+	Retention:  time.Hour,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.Recheck < time.Second {
+		log.Warn("Sanitizing invalid tracker recheck interval", "provided", conf.Recheck, "updated", DefaultConfig.Recheck)
+		conf.Recheck = DefaultConfig.Recheck
+	}
+	if conf.Backoff < time.Second {
+		log.Warn("Sanitizing invalid tracker backoff", "provided", conf.Backoff, "updated", DefaultConfig.Backoff)
+		conf.Backoff = DefaultConfig.Backoff
+	}
+	if conf.MaxBackoff < conf.Backoff {
+		log.Warn("Sanitizing invalid tracker max backoff", "provided", conf.MaxBackoff, "updated", conf.Backoff)
+		conf.MaxBackoff = conf.Backoff
+	}
+	if conf.Retention < 0 {
+		log.Warn("Sanitizing invalid tracker retention", "provided", conf.Retention, "updated", DefaultConfig.Retention)
+		conf.Retention = DefaultConfig.Retention
+	}
+	return conf
+}
+
+// Status is the lifecycle stage of a tracked transaction.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Awaiting inclusion, rebroadcast as needed
+	StatusIncluded  Status = "included"  // Included in the canonical chain
+	StatusReplaced  Status = "replaced"  // Nonce consumed by a different transaction
+	StatusUnknown   Status = "unknown"   // Nonce consumed, but the transaction is not indexed
+	StatusCancelled Status = "cancelled" // Tracking explicitly stopped by the user
+)
+
+// TxInfo reports the tracking state of a local transaction.
+type TxInfo struct {
+	Hash          common.Hash     `json:"hash"`
+	From          common.Address  `json:"from"`
+	Nonce         hexutil.Uint64  `json:"nonce"`
+	Status        Status          `json:"status"`
+	Submitted     time.Time       `json:"submitted"`
+	Broadcasts    hexutil.Uint    `json:"broadcasts"`
+	NextBroadcast *time.Time      `json:"nextBroadcast,omitempty"`
+	BlockNumber   *hexutil.Uint64 `json:"blockNumber,omitempty"`
+}
+
+// trackedTx is a local transaction along with its rebroadcast schedule.
+type trackedTx struct {
+	tx   *types.Transaction
+	from common.Address
+
+	status    Status
+	submitted time.Time // Time the transaction started being tracked
+	attempts  int       // Number of rebroadcasts done so far
+	next      time.Time // Time of the next rebroadcast, if still pending
+	finished  time.Time // Time the transaction left the pending status
+	block     uint64    // Block the transaction was included in
+}
+
+// info assembles the user facing tracking state of the transaction.
+func (track *trackedTx) info() *TxInfo {
+	info := &TxInfo{
+		Hash:       track.tx.Hash(),
+		From:       track.from,
+		Nonce:      hexutil.Uint64(track.tx.Nonce()),
+		Status:     track.status,
+		Submitted:  track.submitted,
+		Broadcasts: hexutil.Uint(track.attempts),
+	}
+	switch track.status {
+	case StatusPending:
+		next := track.next
+		info.NextBroadcast = &next
+	case StatusIncluded:
+		block := hexutil.Uint64(track.block)
+		info.BlockNumber = &block
+	}
+	return info
+}
+
+// TxTracker keeps locally submitted transactions alive independent of the pool:
+// it persists them across restarts, resubmits them if the pool drops them and
+// rebroadcasts them with exponential backoff until they are included in the
+// chain or their tracking is cancelled.
+type TxTracker struct {
+	config    Config
+	chain     Chain
+	pool      Pool
+	broadcast func(types.Transactions) // Network announcer, nil to only resubmit
+	signer    types.Signer
+	journal   *txpool.Journal // Journal of pending transactions, nil if disabled
+
+	txs   map[common.Hash]*trackedTx // All tracked transactions, including finished ones
+	dirty bool                       // Whether the journal needs to be regenerated
+	mu    sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new transaction tracker resubmitting into the given pool and
+// optionally rebroadcasting via the given announcer.
+func New(config Config, chain Chain, pool Pool, broadcast func(types.Transactions)) *TxTracker {
+	config = (&config).sanitize()
+
+	tracker := &TxTracker{
+		config:    config,
+		chain:     chain,
+		pool:      pool,
+		broadcast: broadcast,
+		signer:    types.LatestSigner(chain.Config()),
+		txs:       make(map[common.Hash]*trackedTx),
+		quit:      make(chan struct{}),
+	}
+	if config.Journal != "" {
+		tracker.journal = txpool.NewJournal(config.Journal)
+	}
+	return tracker
+}
+
+// Start loads any previously tracked transactions from the journal and spins up
+// the background rechecks.
+func (tracker *TxTracker) Start() {
+	if tracker.journal != nil {
+		if err := tracker.journal.Load(tracker.TrackAll); err != nil {
+			log.Warn("Failed to load tracked transaction journal", "err", err)
+		}
+		tracker.mu.Lock()
+		tracker.rotate()
+		tracker.mu.Unlock()
+	}
+	tracker.wg.Add(1)
+	go tracker.loop()
+}
+
+// Stop terminates the background rechecks and closes the journal.
+func (tracker *TxTracker) Stop() {
+	close(tracker.quit)
+	tracker.wg.Wait()
+
+	if tracker.journal != nil {
+		tracker.journal.Close()
+	}
+	log.Info("Local transaction tracker stopped")
+}
+
+// Track adds a local transaction to the set of tracked ones.
+func (tracker *TxTracker) Track(tx *types.Transaction) error {
+	return tracker.TrackAll([]*types.Transaction{tx})[0]
+}
+
+// TrackAll adds a batch of local transactions to the set of tracked ones.
+func (tracker *TxTracker) TrackAll(txs []*types.Transaction) []error {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	var (
+		errs = make([]error, len(txs))
+		now  = time.Now()
+	)
+	for i, tx := range txs {
+		if track, ok := tracker.txs[tx.Hash()]; ok && track.status == StatusPending {
+			continue
+		}
+		from, err := types.Sender(tracker.signer, tx)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		tracker.txs[tx.Hash()] = &trackedTx{
+			tx:        tx,
+			from:      from,
+			status:    StatusPending,
+			submitted: now,
+			next:      now.Add(tracker.config.Backoff),
+		}
+		if tracker.journal != nil {
+			if err := tracker.journal.Insert(tx); err != nil {
+				log.Warn("Failed to journal tracked transaction", "err", err)
+			}
+		}
+	}
+	return errs
+}
+
+// Cancel stops tracking a pending transaction. It does not remove the transaction
+// from the pool, it only stops it from being resubmitted or rebroadcast.
+func (tracker *TxTracker) Cancel(hash common.Hash) bool {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	track, ok := tracker.txs[hash]
+	if !ok || track.status != StatusPending {
+		return false
+	}
+	track.status, track.finished = StatusCancelled, time.Now()
+	tracker.dirty = true
+	return true
+}
+
+// Get returns the tracking state of a transaction, or nil if it's not tracked.
+func (tracker *TxTracker) Get(hash common.Hash) *TxInfo {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if track, ok := tracker.txs[hash]; ok {
+		return track.info()
+	}
+	return nil
+}
+
+// All returns the tracking state of all transactions, ordered by sender and nonce.
+func (tracker *TxTracker) All() []*TxInfo {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	infos := make([]*TxInfo, 0, len(tracker.txs))
+	for _, track := range tracker.txs {
+		infos = append(infos, track.info())
+	}
+	slices.SortFunc(infos, func(a, b *TxInfo) int {
+		if c := a.From.Cmp(b.From); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Nonce, b.Nonce); c != 0 {
+			return c
+		}
+		return a.Submitted.Compare(b.Submitted)
+	})
+	return infos
+}
+
+// loop periodically checks the tracked transactions for inclusion and rebroadcasts
+// the ones which are due.
+func (tracker *TxTracker) loop() {
+	defer tracker.wg.Done()
+
+	recheck := time.NewTicker(tracker.config.Recheck)
+	defer recheck.Stop()
+
+	for {
+		select {
+		case <-recheck.C:
+			tracker.recheck(time.Now())
+
+		case <-tracker.quit:
+			return
+		}
+	}
+}
+
+// recheck finalizes the transactions whose nonce has been consumed by the chain,
+// forgets old finished ones and rebroadcasts the pending ones which are due.
+// Transactions of unknown outcome keep being looked up until they are forgotten,
+// in case the index catches up with them.
+func (tracker *TxTracker) recheck(now time.Time) {
+	tracker.mu.Lock()
+
+	// Resolve the outcome of every transaction whose nonce was consumed
+	if head := tracker.chain.CurrentBlock(); head != nil {
+		statedb, err := tracker.chain.StateAt(head.Root)
+		if err != nil {
+			log.Warn("Failed to retrieve head state for tracked transactions", "err", err)
+		} else {
+			// Transactions found in the index are included, the rest are only
+			// known to be replaced if a different one took their nonce slot
+			var (
+				missing  []*trackedTx
+				consumed = make(map[common.Address]map[uint64]bool)
+			)
+			for hash, track := range tracker.txs {
+				if (track.status != StatusPending && track.status != StatusUnknown) || statedb.GetNonce(track.from) <= track.tx.Nonce() {
+					continue
+				}
+				entry, _, err := tracker.chain.GetTransactionLookup(hash)
+				if err != nil {
+					continue // Indexing in progress, decide later
+				}
+				if entry == nil {
+					missing = append(missing, track)
+					continue
+				}
+				if track.status == StatusPending {
+					track.finished = now
+				}
+				track.status, track.block = StatusIncluded, entry.BlockIndex
+				includedMeter.Mark(1)
+				tracker.dirty = true
+			}
+			for _, track := range tracker.txs {
+				if track.status == StatusIncluded {
+					if consumed[track.from] == nil {
+						consumed[track.from] = make(map[uint64]bool)
+					}
+					consumed[track.from][track.tx.Nonce()] = true
+				}
+			}
+			for _, track := range missing {
+				switch {
+				case consumed[track.from][track.tx.Nonce()]:
+					if track.status == StatusPending {
+						track.finished = now
+					}
+					track.status = StatusReplaced
+					replacedMeter.Mark(1)
+
+				case track.status == StatusPending:
+					// The nonce was consumed by something not in the index, which
+					// might just be unindexed or pruned, so don't claim a replacement
+					track.status, track.finished = StatusUnknown, now
+					unknownMeter.Mark(1)
+
+				default:
+					continue
+				}
+				tracker.dirty = true
+			}
+		}
+	}
+	// Gather the pending transactions due for a rebroadcast and forget the
+	// finished ones which have been reported for long enough
+	var (
+		due     []*trackedTx
+		pending int
+	)
+	for hash, track := range tracker.txs {
+		if track.status != StatusPending {
+			if now.Sub(track.finished) > tracker.config.Retention {
+				delete(tracker.txs, hash)
+			}
+			continue
+		}
+		pending++
+		if now.Before(track.next) {
+			continue
+		}
+		track.attempts++
+		track.next = now.Add(tracker.backoff(track.attempts))
+		due = append(due, track)
+	}
+	trackedGauge.Update(int64(pending))
+
+	if tracker.dirty {
+		tracker.rotate()
+	}
+	tracker.mu.Unlock()
+
+	// Resubmit the dropped transactions and announce the pooled ones again,
+	// in nonce order to avoid creating gaps on the receiving end
+	slices.SortFunc(due, func(a, b *trackedTx) int {
+		if c := a.from.Cmp(b.from); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.tx.Nonce(), b.tx.Nonce())
+	})
+	var resubmit, rebroadcast types.Transactions
+	for _, track := range due {
+		if tracker.pool.Has(track.tx.Hash()) {
+			rebroadcast = append(rebroadcast, track.tx)
+		} else {
+			resubmit = append(resubmit, track.tx)
+		}
+	}
+	if len(resubmit) > 0 {
+		for i, err := range tracker.pool.Add(resubmit, true, false) {
+			if err != nil {
+				log.Debug("Failed to resubmit tracked transaction", "hash", resubmit[i].Hash(), "err", err)
+			}
+		}
+		resubmitMeter.Mark(int64(len(resubmit)))
+	}
+	if len(rebroadcast) > 0 && tracker.broadcast != nil {
+		tracker.broadcast(rebroadcast)
+		rebroadcastMeter.Mark(int64(len(rebroadcast)))
+	}
+}
+
+// backoff returns the delay before the next rebroadcast, doubling after every
+// attempt up to the configured maximum.
+func (tracker *TxTracker) backoff(attempts int) time.Duration {
+	delay := tracker.config.Backoff
+	for i := 0; i < attempts && delay < tracker.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, tracker.config.MaxBackoff)
+}
+
+// rotate regenerates the journal from the still pending transactions.
+//
+// Note, this method assumes the tracker lock is held!
+func (tracker *TxTracker) rotate() {
+	tracker.dirty = false
+	if tracker.journal == nil {
+		return
+	}
+	all := make(map[common.Address]types.Transactions)
+	for _, track := range tracker.txs {
+		if track.status == StatusPending {
+			all[track.from] = append(all[track.from], track.tx)
+		}
+	}
+	for _, txs := range all {
+		slices.SortFunc(txs, func(a, b *types.Transaction) int {
+			return cmp.Compare(a.Nonce(), b.Nonce())
+		})
+	}
+	if err := tracker.journal.Rotate(all); err != nil {
+		log.Warn("Failed to rotate tracked transaction journal", "err", err)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package locals
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testChain is a mock chain with a mutable head state and transaction index.
+type testChain struct {
+	statedb  *state.StateDB
+	included map[common.Hash]uint64
+}
+
+func newTestChain() *testChain {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	return &testChain{statedb: statedb, included: make(map[common.Hash]uint64)}
+}
+
+func (c *testChain) Config() *params.ChainConfig { return params.TestChainConfig }
+
+func (c *testChain) CurrentBlock() *types.Header { return &types.Header{Number: new(big.Int)} }
+
+func (c *testChain) StateAt(common.Hash) (*state.StateDB, error) { return c.statedb, nil }
+
+func (c *testChain) GetTransactionLookup(hash common.Hash) (*rawdb.LegacyTxLookupEntry, *types.Transaction, error) {
+	if number, ok := c.included[hash]; ok {
+		return &rawdb.LegacyTxLookupEntry{BlockIndex: number}, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// testPool is a mock pool recording the resubmitted transactions.
+type testPool struct {
+	pooled map[common.Hash]bool
+	added  []*types.Transaction
+}
+
+func (p *testPool) Has(hash common.Hash) bool { return p.pooled[hash] }
+
+func (p *testPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	for _, tx := range txs {
+		p.pooled[tx.Hash()] = true
+	}
+	p.added = append(p.added, txs...)
+	return make([]error, len(txs))
+}
+
+func transaction(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	return pricedTransaction(t, key, nonce, big.NewInt(params.GWei))
+}
+
+func pricedTransaction(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, gasprice *big.Int) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &common.Address{},
+		Gas:      params.TxGas,
+		GasPrice: gasprice,
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	return tx
+}
+
+// Tests that tracked transactions are resubmitted if the pool drops them and
+// rebroadcast otherwise, backing off exponentially between the attempts.
+func TestTrackerRebroadcast(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _    = crypto.GenerateKey()
+		tx        = transaction(t, key, 0)
+		pool      = &testPool{pooled: make(map[common.Hash]bool)}
+		announced []*types.Transaction
+	)
+	config := DefaultConfig
+	config.Journal = ""
+
+	tracker := New(config, newTestChain(), pool, func(txs types.Transactions) {
+		announced = append(announced, txs...)
+	})
+	if err := tracker.Track(tx); err != nil {
+		t.Fatalf("failed to track transaction: %v", err)
+	}
+	start := tracker.Get(tx.Hash()).Submitted
+
+	// Nothing should happen before the initial backoff elapses
+	tracker.recheck(start.Add(config.Backoff - time.Second))
+	if len(pool.added) != 0 || len(announced) != 0 {
+		t.Fatalf("transaction rebroadcast too early: added %d, announced %d", len(pool.added), len(announced))
+	}
+	// The pool lost the transaction, it should be resubmitted
+	tracker.recheck(start.Add(config.Backoff))
+	if len(pool.added) != 1 || len(announced) != 0 {
+		t.Fatalf("dropped transaction not resubmitted: added %d, announced %d", len(pool.added), len(announced))
+	}
+	// The next attempt should be backed off to twice the delay
+	info := tracker.Get(tx.Hash())
+	if want := start.Add(3 * config.Backoff); !info.NextBroadcast.Equal(want) {
+		t.Fatalf("next broadcast mismatch: have %v, want %v", info.NextBroadcast, want)
+	}
+	tracker.recheck(start.Add(3*config.Backoff - time.Second))
+	if len(announced) != 0 {
+		t.Fatalf("transaction rebroadcast before backoff: %d", len(announced))
+	}
+	// Pooled transactions should be announced instead of resubmitted
+	tracker.recheck(start.Add(3 * config.Backoff))
+	if len(pool.added) != 1 || len(announced) != 1 {
+		t.Fatalf("pooled transaction not rebroadcast: added %d, announced %d", len(pool.added), len(announced))
+	}
+	if info := tracker.Get(tx.Hash()); info.Broadcasts != 2 {
+		t.Fatalf("broadcast count mismatch: have %d, want %d", info.Broadcasts, 2)
+	}
+	// The delay should never exceed the configured maximum
+	if delay := tracker.backoff(64); delay != config.MaxBackoff {
+		t.Fatalf("backoff not capped: have %v, want %v", delay, config.MaxBackoff)
+	}
+}
+
+// Tests that tracked transactions are finalized once their nonce is consumed and
+// forgotten after the retention period. Transactions are only reported replaced
+// if a different one is known to have taken their nonce.
+func TestTrackerInclusion(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key1, _     = crypto.GenerateKey()
+		key2, _     = crypto.GenerateKey()
+		key3, _     = crypto.GenerateKey()
+		key4, _     = crypto.GenerateKey()
+		included    = transaction(t, key1, 0)
+		replaced    = transaction(t, key2, 0)
+		replacement = pricedTransaction(t, key2, 0, big.NewInt(2*params.GWei))
+		pending     = transaction(t, key3, 0)
+		unindexed   = transaction(t, key4, 0)
+		chain       = newTestChain()
+		pool        = &testPool{pooled: make(map[common.Hash]bool)}
+	)
+	config := DefaultConfig
+	config.Journal = ""
+
+	tracker := New(config, chain, pool, nil)
+	tracker.TrackAll([]*types.Transaction{included, replaced, replacement, pending, unindexed})
+
+	chain.statedb.SetNonce(crypto.PubkeyToAddress(key1.PublicKey), 1)
+	chain.statedb.SetNonce(crypto.PubkeyToAddress(key2.PublicKey), 1)
+	chain.statedb.SetNonce(crypto.PubkeyToAddress(key4.PublicKey), 1)
+	chain.included[included.Hash()] = 7
+	chain.included[replacement.Hash()] = 8
+
+	now := time.Now()
+	tracker.recheck(now)
+
+	if info := tracker.Get(included.Hash()); info.Status != StatusIncluded || info.BlockNumber == nil || *info.BlockNumber != 7 {
+		t.Fatalf("included transaction status mismatch: %+v", info)
+	}
+	if info := tracker.Get(replaced.Hash()); info.Status != StatusReplaced {
+		t.Fatalf("replaced transaction status mismatch: have %v, want %v", info.Status, StatusReplaced)
+	}
+	if info := tracker.Get(pending.Hash()); info.Status != StatusPending {
+		t.Fatalf("pending transaction status mismatch: have %v, want %v", info.Status, StatusPending)
+	}
+	if info := tracker.Get(unindexed.Hash()); info.Status != StatusUnknown {
+		t.Fatalf("unindexed transaction status mismatch: have %v, want %v", info.Status, StatusUnknown)
+	}
+	// Transactions of unknown outcome should be resolved once the index catches up
+	chain.included[unindexed.Hash()] = 9
+	tracker.recheck(now)
+	if info := tracker.Get(unindexed.Hash()); info.Status != StatusIncluded || info.BlockNumber == nil || *info.BlockNumber != 9 {
+		t.Fatalf("late indexed transaction status mismatch: %+v", info)
+	}
+	// Cancelled transactions should no longer be rebroadcast
+	if !tracker.Cancel(pending.Hash()) {
+		t.Fatalf("failed to cancel pending transaction")
+	}
+	if tracker.Cancel(included.Hash()) {
+		t.Fatalf("cancelled finished transaction")
+	}
+	tracker.recheck(now.Add(config.Backoff))
+	if len(pool.added) != 0 {
+		t.Fatalf("finished transactions resubmitted: %d", len(pool.added))
+	}
+	if infos := tracker.All(); len(infos) != 5 {
+		t.Fatalf("tracked transaction count mismatch: have %d, want %d", len(infos), 5)
+	}
+	// Once the retention elapses, the outcomes should be dropped
+	tracker.recheck(time.Now().Add(config.Retention + time.Second))
+	if infos := tracker.All(); len(infos) != 0 {
+		t.Fatalf("finished transactions not forgotten: %d", len(infos))
+	}
+}
+
+// Tests that pending transactions survive a restart through the journal, while
+// finished ones are dropped from it.
+func TestTrackerJournal(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _    = crypto.GenerateKey()
+		kept      = transaction(t, key, 0)
+		cancelled = transaction(t, key, 1)
+		pool      = &testPool{pooled: make(map[common.Hash]bool)}
+	)
+	config := DefaultConfig
+	config.Journal = filepath.Join(t.TempDir(), "tracked.rlp")
+
+	tracker := New(config, newTestChain(), pool, nil)
+	tracker.Start()
+	tracker.TrackAll([]*types.Transaction{kept, cancelled})
+	tracker.Stop()
+
+	tracker = New(config, newTestChain(), pool, nil)
+	tracker.Start()
+	if infos := tracker.All(); len(infos) != 2 {
+		t.Fatalf("journaled transaction count mismatch: have %d, want %d", len(infos), 2)
+	}
+	tracker.Cancel(cancelled.Hash())
+	tracker.recheck(time.Now())
+	tracker.Stop()
+
+	tracker = New(config, newTestChain(), pool, nil)
+	tracker.Start()
+	defer tracker.Stop()
+
+	infos := tracker.All()
+	if len(infos) != 1 || infos[0].Hash != kept.Hash() {
+		t.Fatalf("journaled transactions mismatch: have %v", infos)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxSource is the channel a transaction entered the pool through.
+type TxSource string
+
+const (
+	TxSourceRPC     TxSource = "rpc"     // Submitted locally via the RPC APIs
+	TxSourceP2P     TxSource = "p2p"     // Received from a remote peer
+	TxSourceJournal TxSource = "journal" // Loaded from disk on startup
+)
+
+// TxOrigin describes when and from where a transaction was first seen by the
+// pool.
+type TxOrigin struct {
+	Time   time.Time // Time the transaction was first seen locally
+	Source TxSource  // Channel through which the transaction arrived
+	Peer   string    // Identifier of the delivering peer, only set for TxSourceP2P
+}
+
+// originTracker records the origin of the transactions accepted into the pool.
+// Entries are added on successful insertion and pruned after pool resets, once
+// the transaction is no longer known to any subpool.
+type originTracker struct {
+	origins map[common.Hash]*TxOrigin
+	lock    sync.RWMutex
+}
+
+// newOriginTracker creates an empty transaction origin tracker.
+func newOriginTracker() *originTracker {
+	return &originTracker{
+		origins: make(map[common.Hash]*TxOrigin),
+	}
+}
+
+// track records the origin of the accepted transactions, unless they were seen
+// before already.
+func (t *originTracker) track(txs []*types.Transaction, errs []error, source TxSource, peer string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i, tx := range txs {
+		if errs != nil && errs[i] != nil {
+			continue
+		}
+		hash := tx.Hash()
+		if _, ok := t.origins[hash]; ok {
+			continue
+		}
+		t.origins[hash] = &TxOrigin{Time: tx.Time(), Source: source, Peer: peer}
+	}
+}
+
+// get retrieves the origin of a transaction, or nil if it's not tracked.
+func (t *originTracker) get(hash common.Hash) *TxOrigin {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if origin := t.origins[hash]; origin != nil {
+		cpy := *origin
+		return &cpy
+	}
+	return nil
+}
+
+// prune drops the origins of all transactions no longer known to the pool.
+func (t *originTracker) prune(has func(common.Hash) bool) {
+	t.lock.RLock()
+	var stale []common.Hash
+	for hash := range t.origins {
+		if !has(hash) {
+			stale = append(stale, hash)
+		}
+	}
+	t.lock.RUnlock()
+
+	if len(stale) == 0 {
+		return
+	}
+	t.lock.Lock()
+	for _, hash := range stale {
+		delete(t.origins, hash)
+	}
+	t.lock.Unlock()
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+const (
+	// PriceBumpPercent is the price bump mode requiring replacements to pay a
+	// percentage more than the transaction they replace.
+	PriceBumpPercent = "percent"
+
+	// PriceBumpAbsolute is the price bump mode requiring replacements to pay a
+	// fixed amount of wei per gas more than the transaction they replace.
+	PriceBumpAbsolute = "absolute"
+)
+
+// NewPriceBumpPolicy creates the replace-by-fee rule for the given mode, using
+// percent for percentage bumps and wei for absolute ones.
+func NewPriceBumpPolicy(mode string, percent uint64, wei uint64) (PriceBumpPolicy, error) {
+	switch mode {
+	case PriceBumpPercent:
+		return PercentageBump(percent), nil
+	case PriceBumpAbsolute:
+		return AbsoluteBump(uint256.NewInt(wei)), nil
+	default:
+		return nil, fmt.Errorf("unknown price bump mode %q", mode)
+	}
+}
+
+// ReplacementFees are the pricing parameters of a transaction that are relevant
+// when deciding whether it may replace another one with the same nonce.
+type ReplacementFees struct {
+	GasFeeCap  *uint256.Int // Maximum fee per execution gas
+	GasTipCap  *uint256.Int // Maximum priority fee per execution gas
+	BlobFeeCap *uint256.Int // Maximum fee per blob gas, nil for non-blob transactions
+	L1Cost     *uint256.Int // Rollup data cost, nil if not known by the pool
+}
+
+// NewReplacementFees gathers the replacement relevant pricing of a transaction.
+// The L1 data cost is only filled in if an l1CostFn is provided.
+func NewReplacementFees(tx *types.Transaction, l1CostFn L1CostFunc) *ReplacementFees {
+	fees := &ReplacementFees{
+		GasFeeCap: saturatingFromBig(tx.GasFeeCap()),
+		GasTipCap: saturatingFromBig(tx.GasTipCap()),
+	}
+	if tx.Type() == types.BlobTxType {
+		fees.BlobFeeCap = saturatingFromBig(tx.BlobGasFeeCap())
+	}
+	if l1CostFn != nil {
+		if l1Cost := l1CostFn(tx.RollupCostData()); l1Cost != nil {
+			fees.L1Cost = saturatingFromBig(l1Cost)
+		}
+	}
+	return fees
+}
+
+// saturatingFromBig converts a fee to a uint256, capping values not fitting into
+// 256 bits at the maximum. A capped transaction can't be outbid, which is fine,
+// as such fees are rejected by the pools anyway.
+func saturatingFromBig(fee *big.Int) *uint256.Int {
+	val, overflow := uint256.FromBig(fee)
+	if overflow {
+		val.SetAllOne()
+	}
+	return val
+}
+
+// PriceBumpPolicy is the replace-by-fee rule of a pool: it decides whether a new
+// transaction is priced high enough to replace an already pooled one.
+type PriceBumpPolicy interface {
+	// Replaceable returns nil if a transaction priced at next may replace one
+	// priced at old, or an error wrapping ErrReplaceUnderpriced otherwise.
+	Replaceable(old, next *ReplacementFees) error
+}
+
+// PriceBumpFunc is an adapter to allow the use of ordinary functions as price
+// bump policies.
+type PriceBumpFunc func(old, next *ReplacementFees) error
+
+// Replaceable calls f(old, next).
+func (f PriceBumpFunc) Replaceable(old, next *ReplacementFees) error {
+	return f(old, next)
+}
+
+// PercentageBump returns a policy requiring every fee cap of the replacement to
+// be strictly higher than the old one, and at least percent% above it. This is
+// the default replacement rule of the pools.
+func PercentageBump(percent uint64) PriceBumpPolicy {
+	return percentageBump(percent)
+}
+
+type percentageBump uint64
+
+// Replaceable implements PriceBumpPolicy.
+func (bump percentageBump) Replaceable(old, next *ReplacementFees) error {
+	// We have to ensure that both the new fee cap and tip are higher than the
+	// old ones as well as checking the percentage threshold to ensure that
+	// this is accurate for low (Wei-level) gas price replacements.
+	if err := checkHigher(old, next); err != nil {
+		return err
+	}
+	var (
+		multiplier = uint256.NewInt(100 + uint64(bump))
+		onehundred = uint256.NewInt(100)
+	)
+	// A threshold overflowing 256 bits can't be met by any replacement
+	exceeds := func(next, old *uint256.Int) bool {
+		threshold, overflow := new(uint256.Int).MulDivOverflow(multiplier, old, onehundred)
+		return !overflow && !next.Lt(threshold)
+	}
+	switch {
+	case !exceeds(next.GasFeeCap, old.GasFeeCap):
+		return fmt.Errorf("%w: new tx gas fee cap %v <= %v queued + %d%% replacement penalty", ErrReplaceUnderpriced, next.GasFeeCap, old.GasFeeCap, bump)
+	case !exceeds(next.GasTipCap, old.GasTipCap):
+		return fmt.Errorf("%w: new tx gas tip cap %v <= %v queued + %d%% replacement penalty", ErrReplaceUnderpriced, next.GasTipCap, old.GasTipCap, bump)
+	case old.BlobFeeCap != nil && !exceeds(next.BlobFeeCap, old.BlobFeeCap):
+		return fmt.Errorf("%w: new tx blob gas fee cap %v <= %v queued + %d%% replacement penalty", ErrReplaceUnderpriced, next.BlobFeeCap, old.BlobFeeCap, bump)
+	}
+	return nil
+}
+
+// AbsoluteBump returns a policy requiring every fee cap of the replacement to
+// be strictly higher than the old one, and at least wei above it.
+func AbsoluteBump(wei *uint256.Int) PriceBumpPolicy {
+	return &absoluteBump{wei: new(uint256.Int).Set(wei)}
+}
+
+type absoluteBump struct {
+	wei *uint256.Int
+}
+
+// Replaceable implements PriceBumpPolicy.
+func (bump *absoluteBump) Replaceable(old, next *ReplacementFees) error {
+	if err := checkHigher(old, next); err != nil {
+		return err
+	}
+	threshold := func(fee *uint256.Int) *uint256.Int {
+		sum, overflow := new(uint256.Int).AddOverflow(fee, bump.wei)
+		if overflow {
+			sum.SetAllOne()
+		}
+		return sum
+	}
+	switch {
+	case next.GasFeeCap.Lt(threshold(old.GasFeeCap)):
+		return fmt.Errorf("%w: new tx gas fee cap %v <= %v queued + %v wei replacement penalty", ErrReplaceUnderpriced, next.GasFeeCap, old.GasFeeCap, bump.wei)
+	case next.GasTipCap.Lt(threshold(old.GasTipCap)):
+		return fmt.Errorf("%w: new tx gas tip cap %v <= %v queued + %v wei replacement penalty", ErrReplaceUnderpriced, next.GasTipCap, old.GasTipCap, bump.wei)
+	case old.BlobFeeCap != nil && next.BlobFeeCap.Lt(threshold(old.BlobFeeCap)):
+		return fmt.Errorf("%w: new tx blob gas fee cap %v <= %v queued + %v wei replacement penalty", ErrReplaceUnderpriced, next.BlobFeeCap, old.BlobFeeCap, bump.wei)
+	}
+	return nil
+}
+
+// checkHigher ensures that every fee cap of a replacement is strictly above the
+// corresponding one of the transaction being replaced.
+func checkHigher(old, next *ReplacementFees) error {
+	switch {
+	case next.GasFeeCap.Cmp(old.GasFeeCap) <= 0:
+		return fmt.Errorf("%w: new tx gas fee cap %v <= %v queued", ErrReplaceUnderpriced, next.GasFeeCap, old.GasFeeCap)
+	case next.GasTipCap.Cmp(old.GasTipCap) <= 0:
+		return fmt.Errorf("%w: new tx gas tip cap %v <= %v queued", ErrReplaceUnderpriced, next.GasTipCap, old.GasTipCap)
+	case old.BlobFeeCap != nil && (next.BlobFeeCap == nil || next.BlobFeeCap.Cmp(old.BlobFeeCap) <= 0):
+		return fmt.Errorf("%w: new tx blob gas fee cap %v <= %v queued", ErrReplaceUnderpriced, next.BlobFeeCap, old.BlobFeeCap)
+	}
+	return nil
+}
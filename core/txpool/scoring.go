@@ -0,0 +1,104 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// ScoringFees are the pricing parameters of a transaction that are relevant
+// when ranking it against others competing for block space.
+type ScoringFees struct {
+	Tip    *uint256.Int // Effective miner tip per gas at the current base fee
+	Gas    uint64       // Gas limit of the transaction
+	L1Cost *uint256.Int // Rollup data cost, nil if not known
+}
+
+// NewScoringFees gathers the ranking relevant pricing of a transaction at the
+// given base fee. The L1 data cost is only filled in if an l1CostFn is provided.
+func NewScoringFees(tx *types.Transaction, baseFee *uint256.Int, l1CostFn L1CostFunc) *ScoringFees {
+	fees := &ScoringFees{
+		Tip: EffectiveTip(uint256.MustFromBig(tx.GasFeeCap()), uint256.MustFromBig(tx.GasTipCap()), baseFee),
+		Gas: tx.Gas(),
+	}
+	if l1CostFn != nil {
+		if l1Cost := l1CostFn(tx.RollupCostData()); l1Cost != nil {
+			fees.L1Cost = uint256.MustFromBig(l1Cost)
+		}
+	}
+	return fees
+}
+
+// EffectiveTip returns the miner tip per gas paid by a transaction with the
+// given fee caps at the base fee, zero if the fee cap is below the base fee. A
+// nil base fee means the full tip is paid.
+func EffectiveTip(feeCap, tipCap, baseFee *uint256.Int) *uint256.Int {
+	if baseFee == nil {
+		return new(uint256.Int).Set(tipCap)
+	}
+	if feeCap.Lt(baseFee) {
+		return new(uint256.Int)
+	}
+	tip := new(uint256.Int).Sub(feeCap, baseFee)
+	if tip.Gt(tipCap) {
+		tip.Set(tipCap)
+	}
+	return tip
+}
+
+// ScoringPolicy is the ordering rule of block building and eviction: the higher
+// the score of a transaction, the more valuable it is to include it.
+type ScoringPolicy interface {
+	// Score returns the per gas value of including a transaction priced at
+	// fees.
+	Score(fees *ScoringFees) *uint256.Int
+}
+
+// ScoringFunc is an adapter to allow the use of ordinary functions as scoring
+// policies.
+type ScoringFunc func(fees *ScoringFees) *uint256.Int
+
+// Score calls f(fees).
+func (f ScoringFunc) Score(fees *ScoringFees) *uint256.Int {
+	return f(fees)
+}
+
+// TipScoring returns a policy ranking transactions by their effective miner
+// tip, disregarding any L1 data cost.
+func TipScoring() ScoringPolicy {
+	return ScoringFunc(func(fees *ScoringFees) *uint256.Int {
+		return fees.Tip
+	})
+}
+
+// NetTipScoring returns a policy ranking transactions by their effective miner
+// tip minus the L1 data cost amortized over their gas limit, i.e. by what the
+// sequencer earns per gas after paying for the data availability. Transactions
+// not covering their L1 cost all score zero.
+func NetTipScoring() ScoringPolicy {
+	return ScoringFunc(func(fees *ScoringFees) *uint256.Int {
+		if fees.L1Cost == nil || fees.Gas == 0 {
+			return fees.Tip
+		}
+		perGas := new(uint256.Int).Div(fees.L1Cost, uint256.NewInt(fees.Gas))
+		if perGas.Gt(fees.Tip) {
+			return new(uint256.Int)
+		}
+		return perGas.Sub(fees.Tip, perGas)
+	})
+}
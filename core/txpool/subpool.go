@@ -85,6 +85,48 @@ type PendingFilter struct {
 	OnlyBlobTxs  bool // Return only blob transactions (block blob-space filling)
 }
 
+// ContentCursor is a position in the pool content, which is ordered by status
+// (pending first), sender and nonce.
+type ContentCursor struct {
+	Queued bool           // Whether the position is in the queued section
+	Sender common.Address // Account of the transaction at the position
+	Nonce  uint64         // Nonce of the transaction at the position
+}
+
+// Cmp compares two positions in the content order, returning -1 if c is before
+// other, 1 if it's after and 0 if they are equal.
+func (c ContentCursor) Cmp(other ContentCursor) int {
+	if c.Queued != other.Queued {
+		if c.Queued {
+			return 1
+		}
+		return -1
+	}
+	if cmp := c.Sender.Cmp(other.Sender); cmp != 0 {
+		return cmp
+	}
+	switch {
+	case c.Nonce < other.Nonce:
+		return -1
+	case c.Nonce > other.Nonce:
+		return 1
+	}
+	return 0
+}
+
+// ContentItem is a single transaction of the pool content along with its
+// position in the content order.
+type ContentItem struct {
+	Queued bool               // Whether the transaction is queued (non-executable)
+	Sender common.Address     // Account the transaction was sent from
+	Tx     *types.Transaction // Transaction itself
+}
+
+// Cursor returns the position of the item in the content order.
+func (item *ContentItem) Cursor() ContentCursor {
+	return ContentCursor{Queued: item.Queued, Sender: item.Sender, Nonce: item.Tx.Nonce()}
+}
+
 // SubPool represents a specialized transaction pool that lives on its own (e.g.
 // blob pool). Since independent of how many specialized pools we have, they do
 // need to be updated in lockstep and assemble into one coherent view for block
@@ -156,6 +198,11 @@ type SubPool interface {
 	// pending as well as queued transactions of this address, grouped by nonce.
 	ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction)
 
+	// ContentRange retrieves at most limit transactions passing the filter, in
+	// content order and starting after the given position (nil for the start).
+	// If sender is set, only the transactions of that account are considered.
+	ContentRange(after *ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []ContentItem
+
 	// Locals retrieves the accounts currently considered local by the pool.
 	Locals() []common.Address
 
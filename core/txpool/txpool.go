@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -72,6 +73,8 @@ type TxPool struct {
 	reservations map[common.Address]SubPool // Map with the account to pool reservations
 	reserveLock  sync.Mutex                 // Lock protecting the account reservations
 
+	origins *originTracker // Arrival time and source of the pooled transactions
+
 	subs event.SubscriptionScope // Subscription scope to unsubscribe all on shutdown
 	quit chan chan error         // Quit channel to tear down the head updater
 	term chan struct{}           // Termination channel to detect a closed pool
@@ -90,6 +93,7 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 	pool := &TxPool{
 		subpools:     subpools,
 		reservations: make(map[common.Address]SubPool),
+		origins:      newOriginTracker(),
 		quit:         make(chan chan error),
 		term:         make(chan struct{}),
 		sync:         make(chan chan error),
@@ -102,6 +106,14 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 			return nil, err
 		}
 	}
+	// Anything in the subpools right after initialization was loaded from disk
+	pending, queued := pool.Content()
+	for _, txs := range pending {
+		pool.origins.track(txs, nil, TxSourceJournal, "")
+	}
+	for _, txs := range queued {
+		pool.origins.track(txs, nil, TxSourceJournal, "")
+	}
 	go pool.loop(head, chain)
 	return pool, nil
 }
@@ -225,6 +237,7 @@ func (p *TxPool) loop(head *types.Header, chain BlockChain) {
 					for _, subpool := range p.subpools {
 						subpool.Reset(oldHead, newHead)
 					}
+					p.origins.prune(p.Has)
 					resetDone <- newHead
 				}(oldHead, newHead)
 
@@ -311,6 +324,25 @@ func (p *TxPool) Get(hash common.Hash) *types.Transaction {
 // to the large transaction churn, add may postpone fully integrating the tx
 // to a later point to batch multiple ones together.
 func (p *TxPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	source := TxSourceP2P
+	if local {
+		source = TxSourceRPC
+	}
+	errs := p.add(txs, local, sync)
+	p.origins.track(txs, errs, source, "")
+	return errs
+}
+
+// AddFromPeer enqueues a batch of remote transactions delivered by the given
+// peer into the pool, recording the peer as their origin.
+func (p *TxPool) AddFromPeer(peer string, txs []*types.Transaction) []error {
+	errs := p.add(txs, false, false)
+	p.origins.track(txs, errs, TxSourceP2P, peer)
+	return errs
+}
+
+// add splits a batch of transactions across the subpools and inserts them.
+func (p *TxPool) add(txs []*types.Transaction, local bool, sync bool) []error {
 	// Split the input transactions between the subpools. It shouldn't really
 	// happen that we receive merged batches, but better graceful than strange
 	// errors.
@@ -438,6 +470,28 @@ func (p *TxPool) ContentFrom(addr common.Address) ([]*types.Transaction, []*type
 	return []*types.Transaction{}, []*types.Transaction{}
 }
 
+// ContentRange retrieves at most limit transactions passing the filter, ordered
+// by status (pending first), sender and nonce and starting after the given
+// position (nil for the start). If sender is set, only the transactions of that
+// account are considered.
+func (p *TxPool) ContentRange(after *ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []ContentItem {
+	var items []ContentItem
+	for _, subpool := range p.subpools {
+		items = append(items, subpool.ContentRange(after, sender, limit, filter)...)
+	}
+	// Accounts are exclusive to a subpool, so merging the individually ordered
+	// ranges only needs a sort and a cut back to the requested limit
+	if len(p.subpools) > 1 {
+		slices.SortFunc(items, func(a, b ContentItem) int {
+			return a.Cursor().Cmp(b.Cursor())
+		})
+	}
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
 // Locals retrieves the accounts currently considered local by the pool.
 func (p *TxPool) Locals() []common.Address {
 	// Retrieve the locals from each subpool and deduplicate them
@@ -455,6 +509,12 @@ func (p *TxPool) Locals() []common.Address {
 	return flat
 }
 
+// Origin returns the arrival time and source of a pooled transaction, or nil if
+// the transaction is unknown.
+func (p *TxPool) Origin(hash common.Hash) *TxOrigin {
+	return p.origins.get(hash)
+}
+
 // Status returns the known status (unknown/pending/queued) of a transaction
 // identified by its hash.
 func (p *TxPool) Status(hash common.Hash) TxStatus {
@@ -66,6 +66,8 @@ type ValidationOptions struct {
 	MinTip  *big.Int // Minimum gas tip needed to allow a transaction into the caller pool
 
 	EffectiveGasCeil uint64 // if non-zero, a gas ceiling to enforce independent of the header's gaslimit value
+
+	Cache *ValidationCache // Optional memo of the static checks, nil to always run them
 }
 
 // ValidateTransaction is a helper method to check whether a transaction is valid
@@ -116,30 +118,12 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if EffectiveGasLimit(opts.Config, head.GasLimit, opts.EffectiveGasCeil) < tx.Gas() {
 		return ErrGasLimit
 	}
-	// Sanity check for extremely large numbers (supported by RLP or RPC)
-	if tx.GasFeeCap().BitLen() > 256 {
-		return core.ErrFeeCapVeryHigh
-	}
-	if tx.GasTipCap().BitLen() > 256 {
-		return core.ErrTipVeryHigh
-	}
-	// Ensure gasFeeCap is greater than or equal to gasTipCap
-	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
-		return core.ErrTipAboveFeeCap
-	}
-	// Make sure the transaction is signed properly
-	if _, err := types.Sender(signer, tx); err != nil {
-		return ErrInvalidSender
-	}
-	// Ensure the transaction has more gas than the bare minimum needed to cover
-	// the transaction metadata
-	intrGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, opts.Config.IsIstanbul(head.Number), opts.Config.IsShanghai(head.Number, head.Time))
-	if err != nil {
+	// Run the costlier checks depending only on the transaction and the fork
+	// rules, reusing any previous result if the transaction was already seen
+	rules := newValidationRules(opts.Config, head)
+	if err := opts.Cache.validate(tx, rules, func() error { return validateStatic(tx, rules, signer) }); err != nil {
 		return err
 	}
-	if tx.Gas() < intrGas {
-		return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas)
-	}
 	// Ensure the gasprice is high enough to cover the requirement of the calling pool
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
 		return fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrUnderpriced, tx.GasTipCap(), opts.MinTip)
@@ -170,6 +154,37 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	return nil
 }
 
+// validateStatic checks the fee sanity, signature and intrinsic gas rules of a
+// transaction, which don't depend on anything but the transaction itself and the
+// active forks.
+func validateStatic(tx *types.Transaction, rules validationRules, signer types.Signer) error {
+	// Sanity check for extremely large numbers (supported by RLP or RPC)
+	if tx.GasFeeCap().BitLen() > 256 {
+		return core.ErrFeeCapVeryHigh
+	}
+	if tx.GasTipCap().BitLen() > 256 {
+		return core.ErrTipVeryHigh
+	}
+	// Ensure gasFeeCap is greater than or equal to gasTipCap
+	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
+		return core.ErrTipAboveFeeCap
+	}
+	// Make sure the transaction is signed properly
+	if _, err := types.Sender(signer, tx); err != nil {
+		return ErrInvalidSender
+	}
+	// Ensure the transaction has more gas than the bare minimum needed to cover
+	// the transaction metadata
+	intrGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, rules&rulesIstanbul != 0, rules&rulesShanghai != 0)
+	if err != nil {
+		return err
+	}
+	if tx.Gas() < intrGas {
+		return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas)
+	}
+	return nil
+}
+
 func validateBlobSidecar(hashes []common.Hash, sidecar *types.BlobTxSidecar) error {
 	if len(sidecar.Blobs) != len(hashes) {
 		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sidecar.Blobs), len(hashes))
@@ -225,6 +240,30 @@ type ValidationOptionsWithState struct {
 
 	// L1CostFn is an optional extension, to validate L1 rollup costs of a tx
 	L1CostFn L1CostFunc
+
+	// Validators is an optional list of external admission hooks, consulted in
+	// order once all the built-in stateful checks have passed.
+	Validators []TxValidator
+}
+
+// TxValidator is an admission hook that external code can register with a pool
+// to accept or reject transactions based on custom rules (e.g. compliance
+// filters or policy engines). It is invoked after the intrinsic and stateful
+// checks with the already recovered sender and the pool's current head state.
+//
+// Note, the state must be treated as read-only and the hook must be cheap, as it
+// runs with the pool lock held.
+type TxValidator interface {
+	ValidateTx(tx *types.Transaction, from common.Address, state *state.StateDB) error
+}
+
+// TxValidatorFunc is an adapter to allow the use of ordinary functions as
+// transaction admission hooks.
+type TxValidatorFunc func(tx *types.Transaction, from common.Address, state *state.StateDB) error
+
+// ValidateTx implements TxValidator, calling f(tx, from, state).
+func (f TxValidatorFunc) ValidateTx(tx *types.Transaction, from common.Address, state *state.StateDB) error {
+	return f(tx, from, state)
 }
 
 // ValidateTransactionWithState is a helper method to check whether a transaction
@@ -284,5 +323,12 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 			return fmt.Errorf("%w: pooled %d txs", ErrAccountLimitExceeded, used)
 		}
 	}
+	// Run any externally registered admission hooks last, so that they only see
+	// transactions which are otherwise acceptable
+	for _, validator := range opts.Validators {
+		if err := validator.ValidateTx(tx, from, opts.State); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,97 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	validationCacheHitMeter  = metrics.NewRegisteredMeter("txpool/validation/cache/hit", nil)
+	validationCacheMissMeter = metrics.NewRegisteredMeter("txpool/validation/cache/miss", nil)
+)
+
+// validationRules is a bitmap of the forks the static validity of a transaction
+// depends on.
+type validationRules uint8
+
+const (
+	rulesIstanbul validationRules = 1 << iota
+	rulesShanghai
+)
+
+// newValidationRules collects the forks active at the given head.
+func newValidationRules(config *params.ChainConfig, head *types.Header) validationRules {
+	var rules validationRules
+	if config.IsIstanbul(head.Number) {
+		rules |= rulesIstanbul
+	}
+	if config.IsShanghai(head.Number, head.Time) {
+		rules |= rulesShanghai
+	}
+	return rules
+}
+
+// validationKey identifies a static validation result: the same transaction may
+// only be valid under a different set of fork rules.
+type validationKey struct {
+	hash  common.Hash
+	rules validationRules
+}
+
+// ValidationCache memoizes the outcome of the costlier static, state independent
+// admission checks of transactions (fee sanity, signature and intrinsic gas), so
+// that a transaction submitted again, e.g. rebroadcast after being dropped from
+// the pool, is not revalidated from scratch.
+//
+// The cache only serves pool admission: promotion and demotion only rerun the
+// head and state dependent checks, and block building enforces the consensus
+// rules during the state transition, neither of which can be memoized per
+// transaction.
+//
+// A cache must only be shared between callers using the same chain config and
+// signer. Blob sidecars are not covered, since they are not part of the hash.
+type ValidationCache struct {
+	results *lru.Cache[validationKey, error]
+}
+
+// NewValidationCache creates a cache retaining up to size validation results.
+func NewValidationCache(size int) *ValidationCache {
+	return &ValidationCache{results: lru.NewCache[validationKey, error](size)}
+}
+
+// validate returns the cached static validation result of a transaction under
+// the given rules, running and caching the checks if not yet known.
+func (c *ValidationCache) validate(tx *types.Transaction, rules validationRules, check func() error) error {
+	if c == nil {
+		return check()
+	}
+	key := validationKey{hash: tx.Hash(), rules: rules}
+	if err, ok := c.results.Get(key); ok {
+		validationCacheHitMeter.Mark(1)
+		return err
+	}
+	validationCacheMissMeter.Mark(1)
+
+	err := check()
+	c.results.Add(key, err)
+	return err
+}
@@ -22,10 +22,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -141,3 +147,169 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// TxPoolLimits retrieves the currently enforced slot limits of the transaction pool.
+func (api *AdminAPI) TxPoolLimits() legacypool.Limits {
+	return api.eth.legacyPool.Limits()
+}
+
+// SetTxPoolLimits updates the slot limits of the transaction pool without a node
+// restart, evicting any transactions in excess of the new limits. Omitted (zero)
+// limits are left unchanged. The effective limits are returned.
+func (api *AdminAPI) SetTxPoolLimits(limits legacypool.Limits) legacypool.Limits {
+	return api.eth.legacyPool.SetLimits(limits)
+}
+
+// maxTopSenders is the number of accounts reported by TxPoolTopSenders if no or
+// an excessive count is requested.
+const maxTopSenders = 100
+
+// TxPoolTopSenders returns the accounts with the most transactions in the pool,
+// along with their aggregate gas and the rate at which they push transactions.
+func (api *AdminAPI) TxPoolTopSenders(count *hexutil.Uint) []legacypool.SenderStats {
+	n := maxTopSenders
+	if count != nil && *count > 0 && int(*count) < maxTopSenders {
+		n = int(*count)
+	}
+	return api.eth.legacyPool.TopSenders(n)
+}
+
+// TxUnderpricedConfig retrieves the parameters of the set of transactions recently
+// rejected as underpriced, which are not fetched again from the network.
+func (api *AdminAPI) TxUnderpricedConfig() fetcher.UnderpricedConfig {
+	return api.eth.handler.txFetcher.UnderpricedConfig()
+}
+
+// SetTxUnderpricedConfig updates the size and timeout of the underpriced set
+// without a node restart. Omitted (zero) fields are left unchanged. The effective
+// parameters are returned.
+func (api *AdminAPI) SetTxUnderpricedConfig(config fetcher.UnderpricedConfig) fetcher.UnderpricedConfig {
+	updated := api.eth.handler.txFetcher.SetUnderpricedConfig(config)
+	log.Info("Underpriced transaction set updated", "size", updated.Size, "timeout", updated.Timeout)
+	return updated
+}
+
+// txPoolEntry is a single transaction of a pool snapshot, along with whether
+// its sender was considered local at the time of the export.
+type txPoolEntry struct {
+	Tx    *types.Transaction
+	Local bool
+}
+
+// ExportTxPool writes all pending and queued transactions of the pool into a
+// local file, so that they can be reinjected via ImportTxPool after a restart
+// or into a standby node. The number of exported transactions is returned.
+//
+// Only the legacy subpool can be exported: the blob pool does not expose its
+// content, so the export fails instead of silently omitting blob transactions
+// if there are any. OP chains have no blob pool and are not affected.
+func (api *AdminAPI) ExportTxPool(file string) (hexutil.Uint, error) {
+	if _, err := os.Stat(file); err == nil {
+		// File already exists. Allowing overwrite could be a DoS vector,
+		// since the 'file' may point to arbitrary paths on the drive.
+		return 0, errors.New("location would overwrite an existing file")
+	}
+	if api.eth.blobPool != nil {
+		if blobs, _ := api.eth.blobPool.Stats(); blobs > 0 {
+			return 0, fmt.Errorf("cannot export %d blob transactions, blob pool content is not exportable", blobs)
+		}
+	}
+	// Snapshot the pool before touching the disk to keep the view consistent
+	var (
+		pool            = api.eth.TxPool()
+		pending, queued = pool.Content()
+		locals          = make(map[common.Address]bool)
+		addrs           = make([]common.Address, 0, len(pending)+len(queued))
+	)
+	for _, addr := range pool.Locals() {
+		locals[addr] = true
+	}
+	for addr := range pending {
+		addrs = append(addrs, addr)
+	}
+	for addr := range queued {
+		if _, ok := pending[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+
+	// Make sure we can create the file to export into
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	var writer io.Writer = out
+	if strings.HasSuffix(file, ".gz") {
+		writer = gzip.NewWriter(writer)
+		defer writer.(*gzip.Writer).Close()
+	}
+	// Export the transactions of each account in nonce order
+	var exported hexutil.Uint
+	for _, addr := range addrs {
+		for _, tx := range append(pending[addr], queued[addr]...) {
+			if err := rlp.Encode(writer, &txPoolEntry{Tx: tx, Local: locals[addr]}); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+	}
+	return exported, nil
+}
+
+// ImportTxPool reinjects the transactions of a pool snapshot created by
+// ExportTxPool. Transactions exported as local are imported as local again.
+// The number of transactions accepted by the pool is returned.
+func (api *AdminAPI) ImportTxPool(file string) (hexutil.Uint, error) {
+	// Make sure the can access the file to import
+	in, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	var reader io.Reader = in
+	if strings.HasSuffix(file, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return 0, err
+		}
+	}
+	// Load the entire snapshot before importing, refusing corrupted files
+	var (
+		stream  = rlp.NewStream(reader, 0)
+		locals  []*types.Transaction
+		remotes []*types.Transaction
+	)
+	for index := 0; ; index++ {
+		entry := new(txPoolEntry)
+		if err := stream.Decode(entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("tx %d: failed to parse: %v", index, err)
+		}
+		if entry.Local {
+			locals = append(locals, entry.Tx)
+		} else {
+			remotes = append(remotes, entry.Tx)
+		}
+	}
+	// Import the transactions, tolerating individual rejections (e.g. already
+	// included or known ones)
+	var imported hexutil.Uint
+	for _, batch := range []struct {
+		txs   []*types.Transaction
+		local bool
+	}{{locals, true}, {remotes, false}} {
+		if len(batch.txs) == 0 {
+			continue
+		}
+		for _, err := range api.eth.TxPool().Add(batch.txs, batch.local, true) {
+			if err == nil {
+				imported++
+			}
+		}
+	}
+	return imported, nil
+}
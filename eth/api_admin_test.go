@@ -0,0 +1,134 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newTestTxPoolBackend creates a minimal Ethereum instance with a live legacy
+// transaction pool on top of a chain funding the given accounts.
+func newTestTxPoolBackend(t *testing.T, funded ...*types.Transaction) *Ethereum {
+	t.Helper()
+
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{},
+	}
+	for _, tx := range funded {
+		from, _ := types.Sender(types.LatestSigner(gspec.Config), tx)
+		gspec.Alloc[from] = types.Account{Balance: big.NewInt(params.Ether)}
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+
+	config := legacypool.DefaultConfig
+	config.Journal = ""
+	legacyPool := legacypool.New(config, chain)
+	pool, err := txpool.New(0, chain, []txpool.SubPool{legacyPool})
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	return &Ethereum{blockchain: chain, txPool: pool, legacyPool: legacyPool}
+}
+
+// signTestTx creates a signed value transfer with the given nonce.
+func signTestTx(t *testing.T, signer types.Signer, key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
+	t.Helper()
+
+	tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &common.Address{},
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.GWei),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	return tx
+}
+
+func TestTxPoolExportImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer       = types.LatestSigner(params.TestChainConfig)
+		localKey, _  = crypto.GenerateKey()
+		remoteKey, _ = crypto.GenerateKey()
+		txs          = []*types.Transaction{
+			signTestTx(t, signer, localKey, 0),
+			signTestTx(t, signer, localKey, 1),
+			signTestTx(t, signer, remoteKey, 0),
+			signTestTx(t, signer, remoteKey, 2), // nonce gap, queued
+		}
+	)
+	source := newTestTxPoolBackend(t, txs...)
+	if errs := source.TxPool().Add(txs[:2], true, true); errs[0] != nil || errs[1] != nil {
+		t.Fatalf("failed to add local transactions: %v", errs)
+	}
+	if errs := source.TxPool().Add(txs[2:], false, true); errs[0] != nil || errs[1] != nil {
+		t.Fatalf("failed to add remote transactions: %v", errs)
+	}
+	for _, name := range []string{"txpool.rlp", "txpool.rlp.gz"} {
+		file := filepath.Join(t.TempDir(), name)
+
+		exported, err := NewAdminAPI(source).ExportTxPool(file)
+		if err != nil {
+			t.Fatalf("%s: failed to export pool: %v", name, err)
+		}
+		if exported != 4 {
+			t.Fatalf("%s: exported transaction mismatch: have %d, want %d", name, exported, 4)
+		}
+		if _, err := NewAdminAPI(source).ExportTxPool(file); err == nil {
+			t.Fatalf("%s: export overwrote existing file", name)
+		}
+		target := newTestTxPoolBackend(t, txs...)
+		imported, err := NewAdminAPI(target).ImportTxPool(file)
+		if err != nil {
+			t.Fatalf("%s: failed to import pool: %v", name, err)
+		}
+		if imported != 4 {
+			t.Fatalf("%s: imported transaction mismatch: have %d, want %d", name, imported, 4)
+		}
+		if pending, queued := target.TxPool().Stats(); pending != 3 || queued != 1 {
+			t.Fatalf("%s: pool stats mismatch: have %d/%d, want %d/%d", name, pending, queued, 3, 1)
+		}
+		locals := target.TxPool().Locals()
+		if len(locals) != 1 || locals[0] != crypto.PubkeyToAddress(localKey.PublicKey) {
+			t.Fatalf("%s: locals mismatch: have %v", name, locals)
+		}
+	}
+}
@@ -306,13 +306,30 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 		// Retain tx in local tx pool after forwarding, for local RPC usage.
 		if err := b.eth.txPool.Add([]*types.Transaction{signedTx}, true, false)[0]; err != nil {
 			log.Warn("successfully sent tx to sequencer, but failed to persist in local tx pool", "err", err, "tx", signedTx.Hash())
+			return nil
 		}
+		b.trackTx(signedTx)
 		return nil
 	}
 	if b.disableTxPool {
 		return nil
 	}
-	return b.eth.txPool.Add([]*types.Transaction{signedTx}, true, false)[0]
+	if err := b.eth.txPool.Add([]*types.Transaction{signedTx}, true, false)[0]; err != nil {
+		return err
+	}
+	b.trackTx(signedTx)
+	return nil
+}
+
+// trackTx hands a locally submitted transaction to the tracker, if enabled, to
+// keep it alive until inclusion.
+func (b *EthAPIBackend) trackTx(tx *types.Transaction) {
+	if b.eth.txTracker == nil {
+		return
+	}
+	if err := b.eth.txTracker.Track(tx); err != nil {
+		log.Warn("Failed to track local transaction", "hash", tx.Hash(), "err", err)
+	}
 }
 
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
@@ -369,6 +386,14 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transac
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+func (b *EthAPIBackend) TxPoolContentRange(after *txpool.ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []txpool.ContentItem {
+	return b.eth.txPool.ContentRange(after, sender, limit, filter)
+}
+
+func (b *EthAPIBackend) TxPoolOrigin(hash common.Hash) *txpool.TxOrigin {
+	return b.eth.txPool.Origin(hash)
+}
+
 func (b *EthAPIBackend) TxPool() *txpool.TxPool {
 	return b.eth.txPool
 }
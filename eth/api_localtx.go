@@ -0,0 +1,67 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool/locals"
+)
+
+// errTrackerDisabled is returned by the local transaction APIs if the node runs
+// without local transaction handling.
+var errTrackerDisabled = errors.New("local transaction tracking disabled")
+
+// LocalTxAPI exposes the state of the locally submitted transactions kept alive
+// by the node until their inclusion.
+type LocalTxAPI struct {
+	eth *Ethereum
+}
+
+// NewLocalTxAPI creates a new LocalTxAPI instance.
+func NewLocalTxAPI(eth *Ethereum) *LocalTxAPI {
+	return &LocalTxAPI{eth: eth}
+}
+
+// Status returns the tracking state of a local transaction, or nil if it's not
+// tracked (anymore).
+func (api *LocalTxAPI) Status(hash common.Hash) (*locals.TxInfo, error) {
+	if api.eth.txTracker == nil {
+		return nil, errTrackerDisabled
+	}
+	return api.eth.txTracker.Get(hash), nil
+}
+
+// Transactions returns the tracking state of all local transactions, ordered by
+// sender and nonce.
+func (api *LocalTxAPI) Transactions() ([]*locals.TxInfo, error) {
+	if api.eth.txTracker == nil {
+		return nil, errTrackerDisabled
+	}
+	return api.eth.txTracker.All(), nil
+}
+
+// Cancel stops resubmitting and rebroadcasting a pending local transaction. The
+// transaction is not removed from the pool and might still get included. The
+// method returns whether a pending transaction was found.
+func (api *LocalTxAPI) Cancel(hash common.Hash) (bool, error) {
+	if api.eth.txTracker == nil {
+		return false, errTrackerDisabled
+	}
+	return api.eth.txTracker.Cancel(hash), nil
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -69,7 +70,10 @@ type Ethereum struct {
 	config *ethconfig.Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool     *txpool.TxPool
+	legacyPool *legacypool.LegacyPool // Legacy subpool, retained for runtime reconfiguration
+	blobPool   *blobpool.BlobPool     // Blob subpool, nil on OP chains which don't accept blobs
+	txTracker  *locals.TxTracker      // Tracker of local transactions, nil if locals are disabled
 
 	blockchain         *core.BlockChain
 	handler            *handler
@@ -264,12 +268,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
-	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	eth.legacyPool = legacypool.New(config.TxPool, eth.blockchain)
 
-	txPools := []txpool.SubPool{legacyPool}
+	txPools := []txpool.SubPool{eth.legacyPool}
 	if !eth.BlockChain().Config().IsOptimism() {
-		blobPool := blobpool.New(config.BlobPool, eth.blockchain)
-		txPools = append(txPools, blobPool)
+		eth.blobPool = blobpool.New(config.BlobPool, eth.blockchain)
+		txPools = append(txPools, eth.blobPool)
 	}
 	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, txPools)
 	if err != nil {
@@ -288,9 +292,21 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
 		NoTxGossip:     config.RollupDisableTxPoolGossip,
+		TxUnderpriced:  config.TxUnderpriced,
 	}); err != nil {
 		return nil, err
 	}
+	// Keep local transactions alive until inclusion, unless locals are disabled
+	if !config.TxPool.NoLocals {
+		if config.TxTracker.Journal != "" {
+			config.TxTracker.Journal = stack.ResolvePath(config.TxTracker.Journal)
+		}
+		var broadcast func(types.Transactions)
+		if !config.RollupDisableTxPoolGossip {
+			broadcast = eth.handler.BroadcastTransactions
+		}
+		eth.txTracker = locals.New(config.TxTracker, eth.blockchain, eth.txPool, broadcast)
+	}
 
 	eth.miner = miner.New(eth, config.Miner, eth.engine)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
@@ -386,6 +402,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "admin",
 			Service:   NewAdminAPI(s),
+		}, {
+			Namespace: "localtx",
+			Service:   NewLocalTxAPI(s),
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
@@ -446,6 +465,11 @@ func (s *Ethereum) Start() error {
 	}
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
+
+	// Start tracking local transactions once they can be announced
+	if s.txTracker != nil {
+		s.txTracker.Start()
+	}
 	return nil
 }
 
@@ -460,6 +484,9 @@ func (s *Ethereum) Stop() error {
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	if s.txTracker != nil {
+		s.txTracker.Stop()
+	}
 	s.txPool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
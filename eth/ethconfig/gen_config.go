@@ -9,7 +9,9 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/miner"
 )
@@ -48,6 +50,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                                   miner.Config
 		TxPool                                  legacypool.Config
 		BlobPool                                blobpool.Config
+		TxTracker                               locals.Config
+		TxUnderpriced                           fetcher.UnderpricedConfig
 		GPO                                     gasprice.Config
 		EnablePreimageRecording                 bool
 		EnableWitnessCollection                 bool `toml:"-"`
@@ -104,6 +108,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.TxTracker = c.TxTracker
+	enc.TxUnderpriced = c.TxUnderpriced
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableWitnessCollection = c.EnableWitnessCollection
@@ -164,6 +170,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                                   *miner.Config
 		TxPool                                  *legacypool.Config
 		BlobPool                                *blobpool.Config
+		TxTracker                               *locals.Config
+		TxUnderpriced                           *fetcher.UnderpricedConfig
 		GPO                                     *gasprice.Config
 		EnablePreimageRecording                 *bool
 		EnableWitnessCollection                 *bool `toml:"-"`
@@ -285,6 +293,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.TxTracker != nil {
+		c.TxTracker = *dec.TxTracker
+	}
+	if dec.TxUnderpriced != nil {
+		c.TxUnderpriced = *dec.TxUnderpriced
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
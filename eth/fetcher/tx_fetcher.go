@@ -23,6 +23,7 @@ import (
 	"math"
 	mrand "math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -55,14 +56,6 @@ const (
 	// a connection between two peers.
 	maxTxRetrievalSize = 128 * 1024
 
-	// maxTxUnderpricedSetSize is the size of the underpriced transaction set that
-	// is used to track recent transactions that have been dropped so we don't
-	// re-request them.
-	maxTxUnderpricedSetSize = 32768
-
-	// maxTxUnderpricedTimeout is the max time a transaction should be stuck in the underpriced set.
-	maxTxUnderpricedTimeout = 5 * time.Minute
-
 	// txArriveTimeout is the time allowance before an announced transaction is
 	// explicitly requested.
 	txArriveTimeout = 500 * time.Millisecond
@@ -78,6 +71,19 @@ var (
 	txFetchTimeout = 5 * time.Second
 )
 
+// UnderpricedConfig are the parameters of the set of transactions recently
+// rejected as underpriced, which are not re-fetched until they expire.
+type UnderpricedConfig struct {
+	Size    int           `json:"size"`    // Maximum number of tracked transactions
+	Timeout time.Duration `json:"timeout"` // Time after which a rejected transaction may be fetched again
+}
+
+// DefaultUnderpricedConfig contains the default underpriced set parameters.
+var DefaultUnderpricedConfig = UnderpricedConfig{
+	Size:    32768,
+	Timeout: 5 * time.Minute,
+}
+
 var (
 	txAnnounceInMeter          = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/in", nil)
 	txAnnounceKnownMeter       = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/known", nil)
@@ -99,6 +105,11 @@ var (
 	txReplyUnderpricedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/underpriced", nil)
 	txReplyOtherRejectMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/replies/otherreject", nil)
 
+	// txUnderpricedFalsePositiveMeter counts the transactions accepted by the pool
+	// while still being marked as underpriced, i.e. wrongly suppressed.
+	txUnderpricedFalsePositiveMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/underpriced/falsepositive", nil)
+	txUnderpricedSizeGauge          = metrics.NewRegisteredGauge("eth/fetcher/transaction/underpriced/size", nil)
+
 	txFetcherWaitingPeers   = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/peers", nil)
 	txFetcherWaitingHashes  = metrics.NewRegisteredGauge("eth/fetcher/transaction/waiting/hashes", nil)
 	txFetcherQueueingPeers  = metrics.NewRegisteredGauge("eth/fetcher/transaction/queueing/peers", nil)
@@ -169,7 +180,9 @@ type TxFetcher struct {
 	drop    chan *txDrop
 	quit    chan struct{}
 
-	underpriced *lru.Cache[common.Hash, time.Time] // Transactions discarded as too cheap (don't re-fetch)
+	underpriced       *lru.Cache[common.Hash, time.Time] // Transactions discarded as too cheap (don't re-fetch)
+	underpricedConfig UnderpricedConfig                  // Size and expiry of the underpriced set
+	underpricedLock   sync.RWMutex                       // Protects the underpriced set during reconfiguration
 
 	// Stage 1: Waiting lists for newly discovered transactions that might be
 	// broadcast without needing explicit request/reply round trips.
@@ -190,10 +203,10 @@ type TxFetcher struct {
 	alternates map[common.Hash]map[string]struct{} // In-flight transaction alternate origins if retrieval fails
 
 	// Callbacks
-	hasTx    func(common.Hash) bool             // Retrieves a tx from the local txpool
-	addTxs   func([]*types.Transaction) []error // Insert a batch of transactions into local txpool
-	fetchTxs func(string, []common.Hash) error  // Retrieves a set of txs from a remote peer
-	dropPeer func(string)                       // Drops a peer in case of announcement violation
+	hasTx    func(common.Hash) bool                     // Retrieves a tx from the local txpool
+	addTxs   func(string, []*types.Transaction) []error // Insert a batch of transactions delivered by a peer into local txpool
+	fetchTxs func(string, []common.Hash) error          // Retrieves a set of txs from a remote peer
+	dropPeer func(string)                               // Drops a peer in case of announcement violation
 
 	step  chan struct{} // Notification channel when the fetcher loop iterates
 	clock mclock.Clock  // Time wrapper to simulate in tests
@@ -202,38 +215,71 @@ type TxFetcher struct {
 
 // NewTxFetcher creates a transaction fetcher to retrieve transaction
 // based on hash announcements.
-func NewTxFetcher(hasTx func(common.Hash) bool, addTxs func([]*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string)) *TxFetcher {
+func NewTxFetcher(hasTx func(common.Hash) bool, addTxs func(string, []*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string)) *TxFetcher {
 	return NewTxFetcherForTests(hasTx, addTxs, fetchTxs, dropPeer, mclock.System{}, nil)
 }
 
 // NewTxFetcherForTests is a testing method to mock out the realtime clock with
 // a simulated version and the internal randomness with a deterministic one.
 func NewTxFetcherForTests(
-	hasTx func(common.Hash) bool, addTxs func([]*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string),
+	hasTx func(common.Hash) bool, addTxs func(string, []*types.Transaction) []error, fetchTxs func(string, []common.Hash) error, dropPeer func(string),
 	clock mclock.Clock, rand *mrand.Rand) *TxFetcher {
 	return &TxFetcher{
-		notify:      make(chan *txAnnounce),
-		cleanup:     make(chan *txDelivery),
-		drop:        make(chan *txDrop),
-		quit:        make(chan struct{}),
-		waitlist:    make(map[common.Hash]map[string]struct{}),
-		waittime:    make(map[common.Hash]mclock.AbsTime),
-		waitslots:   make(map[string]map[common.Hash]*txMetadata),
-		announces:   make(map[string]map[common.Hash]*txMetadata),
-		announced:   make(map[common.Hash]map[string]struct{}),
-		fetching:    make(map[common.Hash]string),
-		requests:    make(map[string]*txRequest),
-		alternates:  make(map[common.Hash]map[string]struct{}),
-		underpriced: lru.NewCache[common.Hash, time.Time](maxTxUnderpricedSetSize),
-		hasTx:       hasTx,
-		addTxs:      addTxs,
-		fetchTxs:    fetchTxs,
-		dropPeer:    dropPeer,
-		clock:       clock,
-		rand:        rand,
+		notify:            make(chan *txAnnounce),
+		cleanup:           make(chan *txDelivery),
+		drop:              make(chan *txDrop),
+		quit:              make(chan struct{}),
+		waitlist:          make(map[common.Hash]map[string]struct{}),
+		waittime:          make(map[common.Hash]mclock.AbsTime),
+		waitslots:         make(map[string]map[common.Hash]*txMetadata),
+		announces:         make(map[string]map[common.Hash]*txMetadata),
+		announced:         make(map[common.Hash]map[string]struct{}),
+		fetching:          make(map[common.Hash]string),
+		requests:          make(map[string]*txRequest),
+		alternates:        make(map[common.Hash]map[string]struct{}),
+		underpriced:       lru.NewCache[common.Hash, time.Time](DefaultUnderpricedConfig.Size),
+		underpricedConfig: DefaultUnderpricedConfig,
+		hasTx:             hasTx,
+		addTxs:            addTxs,
+		fetchTxs:          fetchTxs,
+		dropPeer:          dropPeer,
+		clock:             clock,
+		rand:              rand,
 	}
 }
 
+// UnderpricedConfig returns the current parameters of the underpriced set.
+func (f *TxFetcher) UnderpricedConfig() UnderpricedConfig {
+	f.underpricedLock.RLock()
+	defer f.underpricedLock.RUnlock()
+
+	return f.underpricedConfig
+}
+
+// SetUnderpricedConfig updates the parameters of the underpriced set, leaving
+// any zero fields of the given config unchanged. When shrinking the set, the
+// most recently rejected transactions are retained. The effective parameters
+// are returned.
+func (f *TxFetcher) SetUnderpricedConfig(config UnderpricedConfig) UnderpricedConfig {
+	f.underpricedLock.Lock()
+	defer f.underpricedLock.Unlock()
+
+	if config.Timeout > 0 {
+		f.underpricedConfig.Timeout = config.Timeout
+	}
+	if config.Size > 0 && config.Size != f.underpricedConfig.Size {
+		resized := lru.NewCache[common.Hash, time.Time](config.Size)
+		for _, hash := range f.underpriced.Keys() {
+			if seen, ok := f.underpriced.Peek(hash); ok {
+				resized.Add(hash, seen)
+			}
+		}
+		f.underpriced, f.underpricedConfig.Size = resized, config.Size
+	}
+	txUnderpricedSizeGauge.Update(int64(f.underpriced.Len()))
+	return f.underpricedConfig
+}
+
 // Notify announces the fetcher of the potential availability of a new batch of
 // transactions in the network.
 func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []common.Hash) error {
@@ -285,14 +331,35 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 
 // isKnownUnderpriced reports whether a transaction hash was recently found to be underpriced.
 func (f *TxFetcher) isKnownUnderpriced(hash common.Hash) bool {
+	f.underpricedLock.RLock()
+	defer f.underpricedLock.RUnlock()
+
 	prevTime, ok := f.underpriced.Peek(hash)
-	if ok && prevTime.Before(time.Now().Add(-maxTxUnderpricedTimeout)) {
+	if ok && prevTime.Before(time.Now().Add(-f.underpricedConfig.Timeout)) {
 		f.underpriced.Remove(hash)
 		return false
 	}
 	return ok
 }
 
+// markUnderpriced records the outcome of a pool admission in the underpriced
+// set: rejected cheap transactions are added, accepted ones are removed, which
+// counts as a false positive if they were still marked.
+func (f *TxFetcher) markUnderpriced(tx *types.Transaction, err error) {
+	f.underpricedLock.RLock()
+	defer f.underpricedLock.RUnlock()
+
+	switch {
+	case errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced):
+		f.underpriced.Add(tx.Hash(), tx.Time())
+	case err == nil:
+		if f.underpriced.Remove(tx.Hash()) {
+			txUnderpricedFalsePositiveMeter.Mark(1)
+		}
+	}
+	txUnderpricedSizeGauge.Update(int64(f.underpriced.Len()))
+}
+
 // Enqueue imports a batch of received transaction into the transaction pool
 // and the fetcher. This method may be called by both transaction broadcasts and
 // direct request replies. The differentiation is important so the fetcher can
@@ -332,13 +399,11 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		)
 		batch := txs[i:end]
 
-		for j, err := range f.addTxs(batch) {
+		for j, err := range f.addTxs(peer, batch) {
 			// Track the transaction hash if the price is too low for us.
 			// Avoid re-request this transaction when we receive another
 			// announcement.
-			if errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) {
-				f.underpriced.Add(batch[j].Hash(), batch[j].Time())
-			}
+			f.markUnderpriced(batch[j], err)
 			// Track a few interesting failure types
 			switch {
 			case err == nil: // Noop, but need to handle to not count these
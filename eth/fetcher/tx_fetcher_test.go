@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"math/rand"
 	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -608,7 +609,7 @@ func TestTransactionFetcherCleanup(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -648,7 +649,7 @@ func TestTransactionFetcherCleanupEmpty(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -687,7 +688,7 @@ func TestTransactionFetcherMissingRescheduling(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -734,7 +735,7 @@ func TestTransactionFetcherMissingCleanup(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -773,7 +774,7 @@ func TestTransactionFetcherBroadcasts(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -880,7 +881,7 @@ func TestTransactionFetcherTimeoutRescheduling(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1166,7 +1167,7 @@ func TestTransactionFetcherUnderpricedDedup(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					errs := make([]error, len(txs))
 					for i := 0; i < len(errs); i++ {
 						if i%2 == 0 {
@@ -1208,7 +1209,7 @@ func TestTransactionFetcherUnderpricedDoSProtection(t *testing.T) {
 
 	// Create a slew of transactions to max out the underpriced set
 	var txs []*types.Transaction
-	for i := 0; i < maxTxUnderpricedSetSize+1; i++ {
+	for i := 0; i < DefaultUnderpricedConfig.Size+1; i++ {
 		txs = append(txs, types.NewTransaction(rand.Uint64(), common.Address{byte(rand.Intn(256))}, new(big.Int), 0, new(big.Int), nil))
 	}
 	hashes := make([]common.Hash, len(txs))
@@ -1217,7 +1218,7 @@ func TestTransactionFetcherUnderpricedDoSProtection(t *testing.T) {
 	}
 	// Generate a set of steps to announce and deliver the entire set of transactions
 	var steps []interface{}
-	for i := 0; i < maxTxUnderpricedSetSize/maxTxRetrievals; i++ {
+	for i := 0; i < DefaultUnderpricedConfig.Size/maxTxRetrievals; i++ {
 		steps = append(steps, doTxNotify{peer: "A", hashes: hashes[i*maxTxRetrievals : (i+1)*maxTxRetrievals]})
 		steps = append(steps, isWaiting(map[string][]common.Hash{
 			"A": hashes[i*maxTxRetrievals : (i+1)*maxTxRetrievals],
@@ -1240,7 +1241,7 @@ func TestTransactionFetcherUnderpricedDoSProtection(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					errs := make([]error, len(txs))
 					for i := 0; i < len(errs); i++ {
 						errs[i] = txpool.ErrUnderpriced
@@ -1253,10 +1254,10 @@ func TestTransactionFetcherUnderpricedDoSProtection(t *testing.T) {
 		},
 		steps: append(steps, []interface{}{
 			// The preparation of the test has already been done in `steps`, add the last check
-			doTxNotify{peer: "A", hashes: []common.Hash{hashes[maxTxUnderpricedSetSize]}},
+			doTxNotify{peer: "A", hashes: []common.Hash{hashes[DefaultUnderpricedConfig.Size]}},
 			doWait{time: txArriveTimeout, step: true},
-			doTxEnqueue{peer: "A", txs: []*types.Transaction{txs[maxTxUnderpricedSetSize]}, direct: true},
-			isUnderpriced(maxTxUnderpricedSetSize),
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{txs[DefaultUnderpricedConfig.Size]}, direct: true},
+			isUnderpriced(DefaultUnderpricedConfig.Size),
 		}...),
 	})
 }
@@ -1267,7 +1268,7 @@ func TestTransactionFetcherOutOfBoundDeliveries(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1321,7 +1322,7 @@ func TestTransactionFetcherDrop(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1388,7 +1389,7 @@ func TestTransactionFetcherDropRescheduling(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1434,7 +1435,7 @@ func TestInvalidAnnounceMetadata(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1502,7 +1503,7 @@ func TestTransactionFetcherFuzzCrash01(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1530,7 +1531,7 @@ func TestTransactionFetcherFuzzCrash02(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1560,7 +1561,7 @@ func TestTransactionFetcherFuzzCrash03(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error { return nil },
@@ -1594,7 +1595,7 @@ func TestTransactionFetcherFuzzCrash04(t *testing.T) {
 		init: func() *TxFetcher {
 			return NewTxFetcher(
 				func(common.Hash) bool { return false },
-				func(txs []*types.Transaction) []error {
+				func(peer string, txs []*types.Transaction) []error {
 					return make([]error, len(txs))
 				},
 				func(string, []common.Hash) error {
@@ -1989,7 +1990,7 @@ func containsHashInAnnounces(slice []announce, hash common.Hash) bool {
 func TestTransactionForgotten(t *testing.T) {
 	fetcher := NewTxFetcher(
 		func(common.Hash) bool { return false },
-		func(txs []*types.Transaction) []error {
+		func(peer string, txs []*types.Transaction) []error {
 			errs := make([]error, len(txs))
 			for i := 0; i < len(errs); i++ {
 				errs[i] = txpool.ErrUnderpriced
@@ -2003,7 +2004,7 @@ func TestTransactionForgotten(t *testing.T) {
 	defer fetcher.Stop()
 	// Create one TX which is 5 minutes old, and one which is recent
 	tx1 := types.NewTx(&types.LegacyTx{Nonce: 0})
-	tx1.SetTime(time.Now().Add(-maxTxUnderpricedTimeout - 1*time.Second))
+	tx1.SetTime(time.Now().Add(-DefaultUnderpricedConfig.Timeout - 1*time.Second))
 	tx2 := types.NewTx(&types.LegacyTx{Nonce: 1})
 
 	// Enqueue both in the fetcher. They will be immediately tagged as underpriced
@@ -2019,3 +2020,57 @@ func TestTransactionForgotten(t *testing.T) {
 		t.Fatal("transaction should be known underpriced")
 	}
 }
+
+// Tests that the underpriced set can be reconfigured at runtime, retaining the
+// most recent rejections, and that accepted transactions are dropped from it.
+func TestTransactionUnderpricedConfig(t *testing.T) {
+	var accept atomic.Bool
+	fetcher := NewTxFetcher(
+		func(common.Hash) bool { return false },
+		func(peer string, txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			if !accept.Load() {
+				for i := 0; i < len(errs); i++ {
+					errs[i] = txpool.ErrUnderpriced
+				}
+			}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		func(string) {},
+	)
+	fetcher.Start()
+	defer fetcher.Stop()
+
+	txs := make([]*types.Transaction, 4)
+	for i := range txs {
+		txs[i] = types.NewTx(&types.LegacyTx{Nonce: uint64(i)})
+	}
+	txs[2].SetTime(time.Now().Add(-2 * time.Minute))
+	if err := fetcher.Enqueue("A", txs, false); err != nil {
+		t.Fatal(err)
+	}
+	// Shrinking the set should retain the latest rejections only
+	config := fetcher.SetUnderpricedConfig(UnderpricedConfig{Size: 2})
+	if config.Size != 2 || config.Timeout != DefaultUnderpricedConfig.Timeout {
+		t.Fatalf("config mismatch: have %+v", config)
+	}
+	for i, tx := range txs {
+		if known := fetcher.isKnownUnderpriced(tx.Hash()); known != (i >= 2) {
+			t.Errorf("tx %d: underpriced mismatch: have %v, want %v", i, known, i >= 2)
+		}
+	}
+	// Accepting a transaction still marked underpriced should unmark it
+	accept.Store(true)
+	if err := fetcher.Enqueue("A", txs[3:], false); err != nil {
+		t.Fatal(err)
+	}
+	if fetcher.isKnownUnderpriced(txs[3].Hash()) {
+		t.Errorf("accepted transaction still marked underpriced")
+	}
+	// A shorter timeout should expire the older remaining rejection
+	fetcher.SetUnderpricedConfig(UnderpricedConfig{Timeout: time.Minute})
+	if fetcher.isKnownUnderpriced(txs[2].Hash()) {
+		t.Errorf("expired transaction still marked underpriced")
+	}
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -47,6 +49,20 @@ const maxTopics = 4
 // The maximum number of allowed topics within a topic criteria
 const maxSubTopics = 1000
 
+const (
+	// defaultPendingTxsBuffer is the number of pending transaction notifications
+	// queued up for a slow subscriber if no explicit limit is requested.
+	defaultPendingTxsBuffer = 1024
+
+	// maxPendingTxsBuffer is the maximum number of pending transaction
+	// notifications a single subscription may queue up.
+	maxPendingTxsBuffer = 16384
+)
+
+// droppedPendingTxsMeter counts the pending transaction notifications dropped
+// because a subscriber could not keep up with them.
+var droppedPendingTxsMeter = metrics.NewRegisteredMeter("eth/filters/pending/dropped", nil)
+
 // filter is a helper struct that holds meta information over the filter type
 // and associated subscription in the event system.
 type filter struct {
@@ -149,18 +165,75 @@ func (api *FilterAPI) NewPendingTransactionFilter(fullTx *bool) rpc.ID {
 	return pendingTxSub.ID
 }
 
+// PendingTxsOptions are the parameters of a newPendingTransactions subscription.
+// For backwards compatibility, a plain boolean is accepted as the FullTx flag.
+type PendingTxsOptions struct {
+	FullTx     bool         `json:"fullTx"`     // Deliver full transactions instead of hashes
+	BufferSize hexutil.Uint `json:"bufferSize"` // Notifications queued for a slow subscriber before dropping
+}
+
+// UnmarshalJSON sets *opts fields with the given data.
+func (opts *PendingTxsOptions) UnmarshalJSON(data []byte) error {
+	var fullTx bool
+	if err := json.Unmarshal(data, &fullTx); err == nil {
+		*opts = PendingTxsOptions{FullTx: fullTx}
+		return nil
+	}
+	type options PendingTxsOptions
+	return json.Unmarshal(data, (*options)(opts))
+}
+
+// bufferSize returns the number of notifications to queue for a subscriber.
+func (opts *PendingTxsOptions) bufferSize() int {
+	if opts == nil || opts.BufferSize == 0 {
+		return defaultPendingTxsBuffer
+	}
+	return min(int(opts.BufferSize), maxPendingTxsBuffer)
+}
+
+// queuePendingTxs enqueues a notification for each transaction without blocking
+// and returns the number of transactions dropped because the queue was full.
+// Space is checked before building a notification, so dropped transactions are
+// never marshalled. The caller must be the only sender on the queue.
+func queuePendingTxs(queue chan<- interface{}, txs []*types.Transaction, notification func(*types.Transaction) interface{}) int {
+	var dropped int
+	for _, tx := range txs {
+		if len(queue) == cap(queue) {
+			dropped++
+			continue
+		}
+		queue <- notification(tx)
+	}
+	droppedPendingTxsMeter.Mark(int64(dropped))
+	return dropped
+}
+
 // NewPendingTransactions creates a subscription that is triggered each time a
-// transaction enters the transaction pool. If fullTx is true the full tx is
-// sent to the client, otherwise the hash is sent.
-func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+// transaction enters the transaction pool. If opts.FullTx is true the full tx is
+// sent to the client, otherwise the hash is sent. Notifications exceeding the
+// buffer of a subscriber which cannot keep up are dropped.
+func (api *FilterAPI) NewPendingTransactions(ctx context.Context, opts *PendingTxsOptions) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
-
+	var (
+		fullTx = opts != nil && opts.FullTx
+		buffer = opts.bufferSize()
+	)
 	rpcSub := notifier.CreateSubscription()
 
+	// Deliver the notifications from a separate goroutine so a slow subscriber
+	// only fills its own buffer instead of stalling the event system
+	queue := make(chan interface{}, buffer)
 	go func() {
+		for notification := range queue {
+			notifier.Notify(rpcSub.ID, notification)
+		}
+	}()
+	go func() {
+		defer close(queue)
+
 		txs := make(chan []*types.Transaction, 128)
 		pendingTxSub := api.events.SubscribePendingTxs(txs)
 		defer pendingTxSub.Unsubscribe()
@@ -173,13 +246,15 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 				// To keep the original behaviour, send a single tx hash in one notification.
 				// TODO(rjl493456442) Send a batch of tx hashes in one notification
 				latest := api.sys.backend.CurrentHeader()
-				for _, tx := range txs {
-					if fullTx != nil && *fullTx {
-						rpcTx := ethapi.NewRPCPendingTransaction(tx, latest, chainConfig)
-						notifier.Notify(rpcSub.ID, rpcTx)
-					} else {
-						notifier.Notify(rpcSub.ID, tx.Hash())
+
+				dropped := queuePendingTxs(queue, txs, func(tx *types.Transaction) interface{} {
+					if fullTx {
+						return ethapi.NewRPCPendingTransaction(tx, latest, chainConfig)
 					}
+					return tx.Hash()
+				})
+				if dropped > 0 {
+					log.Debug("Dropped pending transaction notifications", "id", rpcSub.ID, "dropped", dropped, "buffer", buffer)
 				}
 			case <-rpcSub.Err():
 				return
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -183,3 +185,70 @@ func TestUnmarshalJSONNewFilterArgs(t *testing.T) {
 		t.Fatalf("expected 0 topics, got %d topics", len(test7.Topics[2]))
 	}
 }
+
+func TestUnmarshalJSONPendingTxsOptions(t *testing.T) {
+	tests := []struct {
+		input string
+		want  PendingTxsOptions
+	}{
+		{`true`, PendingTxsOptions{FullTx: true}},
+		{`false`, PendingTxsOptions{}},
+		{`{}`, PendingTxsOptions{}},
+		{`{"fullTx":true}`, PendingTxsOptions{FullTx: true}},
+		{`{"fullTx":true,"bufferSize":"0x100"}`, PendingTxsOptions{FullTx: true, BufferSize: 0x100}},
+	}
+	for i, test := range tests {
+		var opts PendingTxsOptions
+		if err := json.Unmarshal([]byte(test.input), &opts); err != nil {
+			t.Fatalf("test %d: failed to unmarshal %s: %v", i, test.input, err)
+		}
+		if opts != test.want {
+			t.Errorf("test %d: options mismatch: have %+v, want %+v", i, opts, test.want)
+		}
+	}
+	var opts PendingTxsOptions
+	if err := json.Unmarshal([]byte(`{"bufferSize":-1}`), &opts); err == nil {
+		t.Errorf("expected error for invalid buffer size")
+	}
+}
+
+// Tests that pending transaction notifications exceeding the buffer of a slow
+// subscriber are dropped and counted, without being built first.
+func TestQueuePendingTxsOverflow(t *testing.T) {
+	if have := (*PendingTxsOptions)(nil).bufferSize(); have != defaultPendingTxsBuffer {
+		t.Fatalf("default buffer size mismatch: have %d, want %d", have, defaultPendingTxsBuffer)
+	}
+	if have := (&PendingTxsOptions{BufferSize: maxPendingTxsBuffer + 1}).bufferSize(); have != maxPendingTxsBuffer {
+		t.Fatalf("capped buffer size mismatch: have %d, want %d", have, maxPendingTxsBuffer)
+	}
+	var (
+		opts  = &PendingTxsOptions{BufferSize: 4}
+		queue = make(chan interface{}, opts.bufferSize())
+		txs   = make([]*types.Transaction, 10)
+		built int
+	)
+	for i := range txs {
+		txs[i] = types.NewTransaction(uint64(i), common.Address{}, common.Big0, 0, common.Big0, nil)
+	}
+	before := droppedPendingTxsMeter.Snapshot().Count()
+	dropped := queuePendingTxs(queue, txs, func(tx *types.Transaction) interface{} {
+		built++
+		return tx.Hash()
+	})
+	if dropped != len(txs)-4 {
+		t.Fatalf("dropped notification count mismatch: have %d, want %d", dropped, len(txs)-4)
+	}
+	if built != 4 {
+		t.Fatalf("built notification count mismatch: have %d, want %d", built, 4)
+	}
+	if metrics.Enabled {
+		if have := droppedPendingTxsMeter.Snapshot().Count() - before; have != int64(dropped) {
+			t.Fatalf("dropped notification meter mismatch: have %d, want %d", have, dropped)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if hash := (<-queue).(common.Hash); hash != txs[i].Hash() {
+			t.Fatalf("notification %d mismatch: have %x, want %x", i, hash, txs[i].Hash())
+		}
+	}
+}
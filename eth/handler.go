@@ -71,6 +71,10 @@ type txPool interface {
 	// Add should add the given transactions to the pool.
 	Add(txs []*types.Transaction, local bool, sync bool) []error
 
+	// AddFromPeer should add the given remote transactions delivered by a peer
+	// to the pool.
+	AddFromPeer(peer string, txs []*types.Transaction) []error
+
 	// Pending should return pending transactions.
 	// The slice should be modifiable by the caller.
 	Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction
@@ -94,6 +98,8 @@ type handlerConfig struct {
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
 	NoTxGossip     bool                   // Disable P2P transaction gossip
+
+	TxUnderpriced fetcher.UnderpricedConfig // Parameters of the underpriced transaction set, zero fields use the defaults
 }
 
 type handler struct {
@@ -200,10 +206,8 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		}
 		return p.RequestTxs(hashes)
 	}
-	addTxs := func(txs []*types.Transaction) []error {
-		return h.txpool.Add(txs, false, false)
-	}
-	h.txFetcher = fetcher.NewTxFetcher(h.txpool.Has, addTxs, fetchTx, h.removePeer)
+	h.txFetcher = fetcher.NewTxFetcher(h.txpool.Has, h.txpool.AddFromPeer, fetchTx, h.removePeer)
+	h.txFetcher.SetUnderpricedConfig(config.TxUnderpriced)
 	return h, nil
 }
 
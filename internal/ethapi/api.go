@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -201,7 +203,7 @@ func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction
 	for account, txs := range pending {
 		dump := make(map[string]*RPCTransaction)
 		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+			dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(api.b, tx, curHeader)
 		}
 		content["pending"][account.Hex()] = dump
 	}
@@ -209,7 +211,7 @@ func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction
 	for account, txs := range queue {
 		dump := make(map[string]*RPCTransaction)
 		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+			dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(api.b, tx, curHeader)
 		}
 		content["queued"][account.Hex()] = dump
 	}
@@ -225,20 +227,127 @@ func (api *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RP
 	// Build the pending transactions
 	dump := make(map[string]*RPCTransaction, len(pending))
 	for _, tx := range pending {
-		dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(api.b, tx, curHeader)
 	}
 	content["pending"] = dump
 
 	// Build the queued transactions
 	dump = make(map[string]*RPCTransaction, len(queue))
 	for _, tx := range queue {
-		dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(api.b, tx, curHeader)
 	}
 	content["queued"] = dump
 
 	return content
 }
 
+// maxTxPoolContentPageSize is the maximum number of transactions returned by a
+// single txpool_contentPaged call, also used if no limit is requested.
+const maxTxPoolContentPageSize = 1024
+
+// TxPoolContentFilter narrows down the transactions returned by ContentPaged.
+// Unset fields match everything.
+type TxPoolContentFilter struct {
+	Sender   *common.Address `json:"sender"`   // Only return transactions from this account
+	MinTip   *hexutil.Big    `json:"minTip"`   // Only return transactions paying at least this effective tip
+	OnlyBlob bool            `json:"onlyBlob"` // Only return blob transactions
+}
+
+// match returns whether the transaction passes the filter, given the base fee
+// of the current head.
+func (f *TxPoolContentFilter) match(tx *types.Transaction, baseFee *big.Int) bool {
+	if f == nil {
+		return true
+	}
+	if f.OnlyBlob && tx.Type() != types.BlobTxType {
+		return false
+	}
+	if f.MinTip != nil {
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil || tip.Cmp(f.MinTip.ToInt()) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TxPoolContentPage is a single page of the transaction pool content, ordered
+// by status (pending first), sender and nonce.
+type TxPoolContentPage struct {
+	Pending map[string]map[string]*RPCTransaction `json:"pending"`
+	Queued  map[string]map[string]*RPCTransaction `json:"queued"`
+	Next    *string                               `json:"next"` // Cursor to retrieve the next page with, nil if exhausted
+}
+
+// parseTxPoolCursor decodes a cursor returned in a previous content page.
+func parseTxPoolCursor(cursor string) (*txpool.ContentCursor, error) {
+	parts := strings.Split(cursor, ":")
+	if len(parts) != 3 || (parts[0] != "pending" && parts[0] != "queued") || !common.IsHexAddress(parts[1]) {
+		return nil, fmt.Errorf("invalid txpool cursor %q", cursor)
+	}
+	nonce, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid txpool cursor %q: %v", cursor, err)
+	}
+	return &txpool.ContentCursor{Queued: parts[0] == "queued", Sender: common.HexToAddress(parts[1]), Nonce: nonce}, nil
+}
+
+// formatTxPoolCursor encodes a cursor into its RPC representation.
+func formatTxPoolCursor(c txpool.ContentCursor) string {
+	status := "pending"
+	if c.Queued {
+		status = "queued"
+	}
+	return fmt.Sprintf("%s:%s:%d", status, c.Sender.Hex(), c.Nonce)
+}
+
+// ContentPaged returns a page of at most limit transactions from the pool that
+// match the optional filter, starting after the position denoted by cursor. An
+// empty cursor starts from the beginning, subsequent pages are retrieved via the
+// cursor returned in the previous page.
+func (api *TxPoolAPI) ContentPaged(cursor string, limit hexutil.Uint, filter *TxPoolContentFilter) (*TxPoolContentPage, error) {
+	var after *txpool.ContentCursor
+	if cursor != "" {
+		var err error
+		if after, err = parseTxPoolCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+	if limit == 0 || limit > maxTxPoolContentPageSize {
+		limit = maxTxPoolContentPageSize
+	}
+	curHeader := api.b.CurrentHeader()
+
+	// Retrieve one transaction more than requested to know whether to continue
+	var sender *common.Address
+	if filter != nil {
+		sender = filter.Sender
+	}
+	items := api.b.TxPoolContentRange(after, sender, int(limit)+1, func(tx *types.Transaction) bool {
+		return filter.match(tx, curHeader.BaseFee)
+	})
+	page := &TxPoolContentPage{
+		Pending: make(map[string]map[string]*RPCTransaction),
+		Queued:  make(map[string]map[string]*RPCTransaction),
+	}
+	if len(items) > int(limit) {
+		next := formatTxPoolCursor(items[limit-1].Cursor())
+		page.Next = &next
+		items = items[:limit]
+	}
+	for _, item := range items {
+		dump := page.Pending
+		if item.Queued {
+			dump = page.Queued
+		}
+		if dump[item.Sender.Hex()] == nil {
+			dump[item.Sender.Hex()] = make(map[string]*RPCTransaction)
+		}
+		dump[item.Sender.Hex()][fmt.Sprintf("%d", item.Tx.Nonce())] = newRPCPoolTransaction(api.b, item.Tx, curHeader)
+	}
+	return page, nil
+}
+
 // Status returns the number of pending and queued transaction in the pool.
 func (api *TxPoolAPI) Status() map[string]hexutil.Uint {
 	pending, queue := api.b.Stats()
@@ -249,8 +358,9 @@ func (api *TxPoolAPI) Status() map[string]hexutil.Uint {
 }
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
-// easily inspectable list.
-func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
+// easily inspectable list. On OP chains, the summaries include the estimated L1
+// data fee and the resulting total cost at the current head.
+func (api *TxPoolAPI) Inspect(ctx context.Context) map[string]map[string]map[string]string {
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
@@ -258,11 +368,27 @@ func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	pending, queue := api.b.TxPoolContent()
 
 	// Define a formatter to flatten a transaction into a string
+	l1Cost := api.l1CostEstimator(ctx)
 	var format = func(tx *types.Transaction) string {
+		var summary string
 		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+			summary = fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+		} else {
+			summary = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+		if l1Cost != nil {
+			if fee, total := l1Cost(tx); fee != nil {
+				summary += fmt.Sprintf(" + %v wei L1 fee = %v wei", fee, total)
+			}
+		}
+		if origin := api.b.TxPoolOrigin(tx.Hash()); origin != nil {
+			source := string(origin.Source)
+			if origin.Peer != "" {
+				source += " " + origin.Peer
+			}
+			summary += fmt.Sprintf(" (seen %s via %s)", origin.Time.UTC().Format(time.RFC3339Nano), source)
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		return summary
 	}
 	// Flatten the pending transactions
 	for account, txs := range pending {
@@ -283,6 +409,36 @@ func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// l1CostEstimator returns a function estimating the L1 data fee of a transaction
+// and its total cost at the effective gas price of the current head, or nil if
+// the chain is not an OP chain or the head state is unavailable.
+func (api *TxPoolAPI) l1CostEstimator(ctx context.Context) func(tx *types.Transaction) (fee, total *big.Int) {
+	if !api.b.ChainConfig().IsOptimism() {
+		return nil
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil || statedb == nil || header == nil {
+		return nil
+	}
+	costFn := types.NewL1CostFunc(api.b.ChainConfig(), statedb)
+	if costFn == nil {
+		return nil
+	}
+	return func(tx *types.Transaction) (*big.Int, *big.Int) {
+		fee := costFn(tx.RollupCostData(), header.Time)
+		if fee == nil {
+			return nil, nil
+		}
+		price := tx.GasPrice()
+		if header.BaseFee != nil {
+			price = math.BigMin(new(big.Int).Add(tx.GasTipCap(), header.BaseFee), tx.GasFeeCap())
+		}
+		total := new(big.Int).Mul(price, new(big.Int).SetUint64(tx.Gas()))
+		total.Add(total, tx.Value())
+		return fee, total.Add(total, fee)
+	}
+}
+
 // EthereumAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type EthereumAccountAPI struct {
@@ -1483,6 +1639,11 @@ type RPCTransaction struct {
 	IsSystemTx *bool        `json:"isSystemTx,omitempty"`
 	// deposit-tx post-Canyon only
 	DepositReceiptVersion *hexutil.Uint64 `json:"depositReceiptVersion,omitempty"`
+
+	// pool-tx only
+	FirstSeen  *hexutil.Uint64 `json:"firstSeen,omitempty"`  // Unix time in milliseconds the local pool first saw the tx
+	Source     string          `json:"source,omitempty"`     // Channel the tx entered the local pool through
+	SourcePeer string          `json:"sourcePeer,omitempty"` // Peer that delivered the tx, if received via p2p
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1608,6 +1769,19 @@ func NewRPCPendingTransaction(tx *types.Transaction, current *types.Header, conf
 	return newRPCTransaction(tx, common.Hash{}, blockNumber, blockTime, 0, baseFee, config, nil)
 }
 
+// newRPCPoolTransaction returns a transaction from the local pool that will
+// serialize to the RPC representation, with its arrival metadata set (if known).
+func newRPCPoolTransaction(b Backend, tx *types.Transaction, current *types.Header) *RPCTransaction {
+	result := NewRPCPendingTransaction(tx, current, b.ChainConfig())
+	if origin := b.TxPoolOrigin(tx.Hash()); origin != nil {
+		seen := hexutil.Uint64(origin.Time.UnixMilli())
+		result.FirstSeen = &seen
+		result.Source = string(origin.Source)
+		result.SourcePeer = origin.Peer
+	}
+	return result
+}
+
 // newRPCTransactionFromBlockIndex returns a transaction that will serialize to the RPC representation.
 func newRPCTransactionFromBlockIndex(ctx context.Context, b *types.Block, index uint64, config *params.ChainConfig, backend Backend) *RPCTransaction {
 	txs := b.Transactions()
@@ -1853,7 +2027,7 @@ func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common
 	if !found {
 		// No finalized transaction, try to retrieve it from the pool
 		if tx := api.b.GetPoolTransaction(hash); tx != nil {
-			return NewRPCPendingTransaction(tx, api.b.CurrentHeader(), api.b.ChainConfig()), nil
+			return newRPCPoolTransaction(api.b, tx, api.b.CurrentHeader()), nil
 		}
 		if err == nil {
 			return nil, nil
@@ -2058,6 +2232,9 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
 	if err := b.SendTx(ctx, tx); err != nil {
+		if errors.Is(err, txpool.ErrOverloaded) {
+			return common.Hash{}, &overloadedError{err}
+		}
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
@@ -2237,7 +2414,7 @@ func (api *TransactionAPI) PendingTransactions() ([]*RPCTransaction, error) {
 	for _, tx := range pending {
 		from, _ := types.Sender(api.signer, tx)
 		if _, exists := accounts[from]; exists {
-			transactions = append(transactions, NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig()))
+			transactions = append(transactions, newRPCPoolTransaction(api.b, tx, curHeader))
 		}
 	}
 	return transactions, nil
@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -47,6 +48,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -761,6 +763,10 @@ func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, m
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	panic("implement me")
 }
+func (b testBackend) TxPoolContentRange(after *txpool.ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []txpool.ContentItem {
+	panic("implement me")
+}
+func (b testBackend) TxPoolOrigin(hash common.Hash) *txpool.TxOrigin { panic("implement me") }
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
@@ -2224,3 +2230,224 @@ func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc s
 	}
 	require.JSONEqf(t, string(want), string(data), "test %d: json not match, want: %s, have: %s", testid, string(want), string(data))
 }
+
+// poolContentBackend is a backend serving a fixed transaction pool content.
+type poolContentBackend struct {
+	Backend
+	pending map[common.Address][]*types.Transaction
+	queued  map[common.Address][]*types.Transaction
+	origins map[common.Hash]*txpool.TxOrigin
+}
+
+func (b *poolContentBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	return b.pending, b.queued
+}
+func (b *poolContentBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	return b.pending[addr], b.queued[addr]
+}
+func (b *poolContentBackend) TxPoolContentRange(after *txpool.ContentCursor, sender *common.Address, limit int, filter func(*types.Transaction) bool) []txpool.ContentItem {
+	var items []txpool.ContentItem
+	for _, section := range []struct {
+		queued  bool
+		content map[common.Address][]*types.Transaction
+	}{{false, b.pending}, {true, b.queued}} {
+		for addr, txs := range section.content {
+			if sender != nil && addr != *sender {
+				continue
+			}
+			for _, tx := range txs {
+				item := txpool.ContentItem{Queued: section.queued, Sender: addr, Tx: tx}
+				if (after == nil || item.Cursor().Cmp(*after) > 0) && filter(tx) {
+					items = append(items, item)
+				}
+			}
+		}
+	}
+	slices.SortFunc(items, func(a, b txpool.ContentItem) int { return a.Cursor().Cmp(b.Cursor()) })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+func (b *poolContentBackend) TxPoolOrigin(hash common.Hash) *txpool.TxOrigin {
+	return b.origins[hash]
+}
+func (b *poolContentBackend) CurrentHeader() *types.Header {
+	return &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(params.InitialBaseFee)}
+}
+func (b *poolContentBackend) ChainConfig() *params.ChainConfig { return params.TestChainConfig }
+
+func TestTxPoolContentPaged(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer = types.LatestSigner(params.TestChainConfig)
+		keys   = make([]*ecdsa.PrivateKey, 3)
+		addrs  = make([]common.Address, 3)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	makeTx := func(key *ecdsa.PrivateKey, nonce uint64, tip int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(params.InitialBaseFee + tip),
+			Gas:       21000,
+			To:        &common.Address{},
+		})
+	}
+	backend := &poolContentBackend{
+		pending: make(map[common.Address][]*types.Transaction),
+		queued:  make(map[common.Address][]*types.Transaction),
+	}
+	for i, key := range keys {
+		backend.pending[addrs[i]] = []*types.Transaction{makeTx(key, 0, 1), makeTx(key, 1, 10)}
+		backend.queued[addrs[i]] = []*types.Transaction{makeTx(key, 5, 1)}
+	}
+	api := NewTxPoolAPI(backend)
+
+	// Page through the entire pool and ensure every transaction is seen once
+	var (
+		cursor string
+		seen   = make(map[common.Hash]bool)
+		pages  int
+	)
+	for {
+		page, err := api.ContentPaged(cursor, 2, nil)
+		if err != nil {
+			t.Fatalf("page %d: failed to retrieve content: %v", pages, err)
+		}
+		pages++
+		for _, section := range []map[string]map[string]*RPCTransaction{page.Pending, page.Queued} {
+			for _, txs := range section {
+				for _, tx := range txs {
+					if seen[tx.Hash] {
+						t.Fatalf("page %d: transaction %x returned twice", pages, tx.Hash)
+					}
+					seen[tx.Hash] = true
+				}
+			}
+		}
+		if page.Next == nil {
+			break
+		}
+		cursor = *page.Next
+	}
+	if len(seen) != 9 {
+		t.Fatalf("paged transaction count mismatch: have %d, want %d", len(seen), 9)
+	}
+	if pages != 5 {
+		t.Fatalf("page count mismatch: have %d, want %d", pages, 5)
+	}
+	// Ensure the filters are applied
+	page, err := api.ContentPaged("", 0, &TxPoolContentFilter{Sender: &addrs[1], MinTip: (*hexutil.Big)(big.NewInt(5))})
+	if err != nil {
+		t.Fatalf("failed to retrieve filtered content: %v", err)
+	}
+	if len(page.Pending) != 1 || len(page.Pending[addrs[1].Hex()]) != 1 || page.Pending[addrs[1].Hex()]["1"] == nil {
+		t.Fatalf("filtered pending content mismatch: %v", page.Pending)
+	}
+	if len(page.Queued) != 0 || page.Next != nil {
+		t.Fatalf("filtered queued content mismatch: %v, next %v", page.Queued, page.Next)
+	}
+	if page, err = api.ContentPaged("", 0, &TxPoolContentFilter{OnlyBlob: true}); err != nil || len(page.Pending)+len(page.Queued) != 0 {
+		t.Fatalf("blob filtered content mismatch: %v %v, err %v", page.Pending, page.Queued, err)
+	}
+	// Cursors of accounts no longer in the pool should resume after them
+	last := common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff").Hex()
+	if page, err = api.ContentPaged("pending:"+last+":0", 0, nil); err != nil || len(page.Pending) != 0 || len(page.Queued) != 3 {
+		t.Fatalf("resumed content mismatch: %v %v, err %v", page.Pending, page.Queued, err)
+	}
+	if _, err := api.ContentPaged("bogus", 0, nil); err == nil {
+		t.Fatalf("invalid cursor accepted")
+	}
+}
+
+func TestTxPoolOrigin(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		signer = types.LatestSigner(params.TestChainConfig)
+		seen   = time.UnixMilli(1700000000123)
+	)
+	tracked := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 0, Gas: 21000, GasPrice: big.NewInt(params.InitialBaseFee), To: &common.Address{}})
+	untracked := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(params.InitialBaseFee), To: &common.Address{}})
+
+	backend := &poolContentBackend{
+		pending: map[common.Address][]*types.Transaction{addr: {tracked, untracked}},
+		queued:  map[common.Address][]*types.Transaction{},
+		origins: map[common.Hash]*txpool.TxOrigin{
+			tracked.Hash(): {Time: seen, Source: txpool.TxSourceP2P, Peer: "deadbeef"},
+		},
+	}
+	api := NewTxPoolAPI(backend)
+
+	content := api.ContentFrom(addr)["pending"]
+	if tx := content["0"]; tx.FirstSeen == nil || uint64(*tx.FirstSeen) != uint64(seen.UnixMilli()) || tx.Source != "p2p" || tx.SourcePeer != "deadbeef" {
+		t.Errorf("tracked transaction origin mismatch: seen %v, source %q, peer %q", tx.FirstSeen, tx.Source, tx.SourcePeer)
+	}
+	if tx := content["1"]; tx.FirstSeen != nil || tx.Source != "" || tx.SourcePeer != "" {
+		t.Errorf("untracked transaction has origin: seen %v, source %q, peer %q", tx.FirstSeen, tx.Source, tx.SourcePeer)
+	}
+	inspect := api.Inspect(context.Background())["pending"][addr.Hex()]
+	if want := "(seen 2023-11-14T22:13:20.123Z via p2p deadbeef)"; !strings.HasSuffix(inspect["0"], want) {
+		t.Errorf("tracked transaction summary mismatch: have %q, want suffix %q", inspect["0"], want)
+	}
+	if strings.Contains(inspect["1"], "seen") {
+		t.Errorf("untracked transaction summary has origin: %q", inspect["1"])
+	}
+}
+
+// opPoolContentBackend is a pool content backend of an OP chain, serving the L1
+// fee parameters from its head state.
+type opPoolContentBackend struct {
+	*poolContentBackend
+	statedb *state.StateDB
+}
+
+func (b *opPoolContentBackend) ChainConfig() *params.ChainConfig { return params.OptimismTestConfig }
+func (b *opPoolContentBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+	return b.statedb, &types.Header{Number: big.NewInt(10), Time: 100}, nil
+}
+
+func TestTxPoolInspectL1Fee(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		tx     = types.MustSignNewTx(key, types.HomesteadSigner{}, &types.LegacyTx{Nonce: 0, Gas: 21000, GasPrice: big.NewInt(7), Value: big.NewInt(3), To: &common.Address{}, Data: []byte{0x01, 0x00}})
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetState(types.L1BlockAddr, types.L1BaseFeeSlot, common.BigToHash(big.NewInt(1000)))
+	statedb.SetState(types.L1BlockAddr, types.OverheadSlot, common.BigToHash(big.NewInt(2100)))
+	statedb.SetState(types.L1BlockAddr, types.ScalarSlot, common.BigToHash(big.NewInt(1_000_000)))
+
+	backend := &opPoolContentBackend{
+		poolContentBackend: &poolContentBackend{
+			pending: map[common.Address][]*types.Transaction{addr: {tx}},
+			queued:  map[common.Address][]*types.Transaction{},
+		},
+		statedb: statedb,
+	}
+	fee := types.NewL1CostFunc(params.OptimismTestConfig, statedb)(tx.RollupCostData(), 100)
+	if fee == nil || fee.Sign() == 0 {
+		t.Fatalf("test setup produced no L1 fee")
+	}
+	total := new(big.Int).Add(big.NewInt(3+21000*7), fee)
+
+	summary := NewTxPoolAPI(backend).Inspect(context.Background())["pending"][addr.Hex()]["0"]
+	if want := fmt.Sprintf(" + %v wei L1 fee = %v wei", fee, total); !strings.HasSuffix(summary, want) {
+		t.Errorf("summary mismatch: have %q, want suffix %q", summary, want)
+	}
+	// Non-OP chains should not report any L1 fee
+	summary = NewTxPoolAPI(backend.poolContentBackend).Inspect(context.Background())["pending"][addr.Hex()]["0"]
+	if strings.Contains(summary, "L1 fee") {
+		t.Errorf("non-OP summary reports L1 fee: %q", summary)
+	}
+}
@@ -76,3 +76,14 @@ func (e *TxIndexingError) ErrorCode() int {
 
 // ErrorData returns the hex encoded revert reason.
 func (e *TxIndexingError) ErrorData() interface{} { return "transaction indexing is in progress" }
+
+// overloadedError is an API error returned when a transaction is rejected by a
+// transaction pool shedding load, so that clients can tell it apart from the
+// transaction being invalid and retry later.
+type overloadedError struct{ error }
+
+// ErrorCode returns the JSON error code for an overloaded pool.
+// See: https://github.com/ethereum/EIPs/blob/master/EIPS/eip-1474.md
+func (e *overloadedError) ErrorCode() int {
+	return -32005 // limit exceeded
+}
@@ -31,6 +31,7 @@ var Modules = map[string]string{
 	"les":      LESJs,
 	"vflux":    VfluxJs,
 	"dev":      DevJs,
+	"localtx":  LocalTxJs,
 }
 
 const CliqueJs = `
@@ -190,6 +191,32 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'setTxPoolLimits',
+			call: 'admin_setTxPoolLimits',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'txPoolTopSenders',
+			call: 'admin_txPoolTopSenders',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'setTxUnderpricedConfig',
+			call: 'admin_setTxUnderpricedConfig',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'exportTxPool',
+			call: 'admin_exportTxPool',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'importTxPool',
+			call: 'admin_importTxPool',
+			params: 1
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -204,6 +231,14 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'txPoolLimits',
+			getter: 'admin_txPoolLimits'
+		}),
+		new web3._extend.Property({
+			name: 'txUnderpricedConfig',
+			getter: 'admin_txUnderpricedConfig'
+		}),
 	]
 });
 `
@@ -780,6 +815,12 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'contentPaged',
+			call: 'txpool_contentPaged',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 	]
 });
 `
@@ -887,3 +928,29 @@ web3._extend({
 	],
 });
 `
+
+const LocalTxJs = `
+web3._extend({
+	property: 'localtx',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'status',
+			call: 'localtx_status',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'cancel',
+			call: 'localtx_cancel',
+			params: 1
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'transactions',
+			getter: 'localtx_transactions'
+		}),
+	]
+});
+`
@@ -55,8 +55,16 @@ type Config struct {
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 
-	RollupComputePendingBlock bool   // Compute the pending block from tx-pool, instead of copying the latest-block
-	EffectiveGasCeil          uint64 // if non-zero, a gas ceiling to apply independent of the header's gaslimit value
+	RollupComputePendingBlock bool             // Compute the pending block from tx-pool, instead of copying the latest-block
+	EffectiveGasCeil          uint64           // if non-zero, a gas ceiling to apply independent of the header's gaslimit value
+	PrioritySenders           []common.Address // Accounts whose transactions are included ahead of all others
+	MaxTxsPerSender           uint64           // Maximum number of transactions included per sender in a block (0 = unlimited)
+	SenderCapExempt           []common.Address // Accounts exempt from the per-sender inclusion cap
+
+	// TxScoring overrides the ordering of transactions in built blocks. If nil,
+	// transactions are ranked by their effective tip, net of the L1 data cost
+	// on rollups.
+	TxScoring txpool.ScoringPolicy `toml:"-"`
 }
 
 // DefaultConfig contains default settings for miner.
@@ -33,24 +33,27 @@ type txWithMinerFee struct {
 	fees *uint256.Int
 }
 
-// newTxWithMinerFee creates a wrapped transaction, calculating the effective
-// miner gasTipCap if a base fee is provided.
+// newTxWithMinerFee creates a wrapped transaction, scoring it by its effective
+// miner gasTipCap if a base fee is provided, and its L1 data cost if an l1CostFn
+// is provided and the transaction is already resolved.
 // Returns error in case of a negative effective miner gasTipCap.
-func newTxWithMinerFee(tx *txpool.LazyTransaction, from common.Address, baseFee *uint256.Int) (*txWithMinerFee, error) {
-	tip := new(uint256.Int).Set(tx.GasTipCap)
-	if baseFee != nil {
-		if tx.GasFeeCap.Cmp(baseFee) < 0 {
-			return nil, types.ErrGasFeeCapTooLow
-		}
-		tip = new(uint256.Int).Sub(tx.GasFeeCap, baseFee)
-		if tip.Gt(tx.GasTipCap) {
-			tip = tx.GasTipCap
+func newTxWithMinerFee(tx *txpool.LazyTransaction, from common.Address, baseFee *uint256.Int, scoring txpool.ScoringPolicy, l1CostFn txpool.L1CostFunc) (*txWithMinerFee, error) {
+	if baseFee != nil && tx.GasFeeCap.Cmp(baseFee) < 0 {
+		return nil, types.ErrGasFeeCapTooLow
+	}
+	fees := &txpool.ScoringFees{
+		Tip: txpool.EffectiveTip(tx.GasFeeCap, tx.GasTipCap, baseFee),
+		Gas: tx.Gas,
+	}
+	if l1CostFn != nil && tx.Tx != nil {
+		if l1Cost := l1CostFn(tx.Tx.RollupCostData()); l1Cost != nil {
+			fees.L1Cost = uint256.MustFromBig(l1Cost)
 		}
 	}
 	return &txWithMinerFee{
 		tx:   tx,
 		from: from,
-		fees: tip,
+		fees: scoring.Score(fees),
 	}, nil
 }
 
@@ -87,27 +90,33 @@ func (s *txByPriceAndTime) Pop() interface{} {
 // transactions in a profit-maximizing sorted order, while supporting removing
 // entire batches of transactions for non-executable accounts.
 type transactionsByPriceAndNonce struct {
-	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
-	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
-	signer  types.Signer                                 // Signer for the set of transactions
-	baseFee *uint256.Int                                 // Current base fee
+	txs      map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads    txByPriceAndTime                             // Next transaction for each unique account (price heap)
+	signer   types.Signer                                 // Signer for the set of transactions
+	baseFee  *uint256.Int                                 // Current base fee
+	scoring  txpool.ScoringPolicy                         // Ranking of the transactions
+	l1CostFn txpool.L1CostFunc                            // Rollup data cost of the transactions, nil if not a rollup
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
-// price sorted transactions in a nonce-honouring way.
+// price sorted transactions in a nonce-honouring way. If no scoring policy is
+// given, transactions are sorted by their effective tip.
 //
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
+func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, scoring txpool.ScoringPolicy, l1CostFn txpool.L1CostFunc) *transactionsByPriceAndNonce {
 	// Convert the basefee from header format to uint256 format
 	var baseFeeUint *uint256.Int
 	if baseFee != nil {
 		baseFeeUint = uint256.MustFromBig(baseFee)
 	}
+	if scoring == nil {
+		scoring = txpool.TipScoring()
+	}
 	// Initialize a price and received time based heap with the head transactions
 	heads := make(txByPriceAndTime, 0, len(txs))
 	for from, accTxs := range txs {
-		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint, scoring, l1CostFn)
 		if err != nil {
 			delete(txs, from)
 			continue
@@ -119,10 +128,12 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 
 	// Assemble and return the transaction set
 	return &transactionsByPriceAndNonce{
-		txs:     txs,
-		heads:   heads,
-		signer:  signer,
-		baseFee: baseFeeUint,
+		txs:      txs,
+		heads:    heads,
+		signer:   signer,
+		baseFee:  baseFeeUint,
+		scoring:  scoring,
+		l1CostFn: l1CostFn,
 	}
 }
 
@@ -138,7 +149,7 @@ func (t *transactionsByPriceAndNonce) Peek() (*txpool.LazyTransaction, *uint256.
 func (t *transactionsByPriceAndNonce) Shift() {
 	acc := t.heads[0].from
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee, t.scoring, t.l1CostFn); err == nil {
 			t.heads[0], t.txs[acc] = wrapped, txs[1:]
 			heap.Fix(&t.heads, 0)
 			return
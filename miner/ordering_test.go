@@ -17,6 +17,7 @@
 package miner
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"math/big"
 	"math/rand"
@@ -102,7 +103,7 @@ func testTransactionPriceNonceSort(t *testing.T, baseFee *big.Int) {
 		expectedCount += count
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := newTransactionsByPriceAndNonce(signer, groups, baseFee)
+	txset := newTransactionsByPriceAndNonce(signer, groups, baseFee, nil, nil)
 
 	txs := types.Transactions{}
 	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
@@ -168,7 +169,7 @@ func TestTransactionTimeSort(t *testing.T) {
 		})
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := newTransactionsByPriceAndNonce(signer, groups, nil)
+	txset := newTransactionsByPriceAndNonce(signer, groups, nil, nil, nil)
 
 	txs := types.Transactions{}
 	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
@@ -194,3 +195,67 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that on rollups transactions are ordered by their tip net of the L1 data
+// cost amortized over their gas, not by their raw tip.
+func TestTransactionNetTipSort(t *testing.T) {
+	t.Parallel()
+
+	var (
+		signer  = types.LatestSignerForChainID(common.Big1)
+		cheap   = makeLazyTx(t, signer, 5, nil)
+		bloated = makeLazyTx(t, signer, 10, bytes.Repeat([]byte{0xff}, 1000))
+	)
+	// Charge 1000 wei per non-zero byte, amortizing to a wei per gas for every
+	// hundred of them
+	l1CostFn := func(rollupCostData types.RollupCostData) *big.Int {
+		return new(big.Int).SetUint64(rollupCostData.Ones * 1000)
+	}
+	order := func(scoring txpool.ScoringPolicy, l1CostFn txpool.L1CostFunc) []common.Hash {
+		groups := map[common.Address][]*txpool.LazyTransaction{}
+		for _, ltx := range []*txpool.LazyTransaction{cheap, bloated} {
+			from, _ := types.Sender(signer, ltx.Tx)
+			groups[from] = []*txpool.LazyTransaction{ltx}
+		}
+		txset := newTransactionsByPriceAndNonce(signer, groups, big.NewInt(0), scoring, l1CostFn)
+
+		var hashes []common.Hash
+		for ltx, _ := txset.Peek(); ltx != nil; ltx, _ = txset.Peek() {
+			hashes = append(hashes, ltx.Hash)
+			txset.Shift()
+		}
+		return hashes
+	}
+	if have := order(nil, nil); have[0] != bloated.Hash {
+		t.Errorf("raw tip ordering mismatch: have %x first, want %x", have[0], bloated.Hash)
+	}
+	if have := order(txpool.NetTipScoring(), l1CostFn); have[0] != cheap.Hash {
+		t.Errorf("net tip ordering mismatch: have %x first, want %x", have[0], cheap.Hash)
+	}
+	if have := order(txpool.TipScoring(), l1CostFn); have[0] != bloated.Hash {
+		t.Errorf("custom scoring ordering mismatch: have %x first, want %x", have[0], bloated.Hash)
+	}
+}
+
+func makeLazyTx(t *testing.T, signer types.Signer, tip int64, data []byte) *txpool.LazyTransaction {
+	key, _ := crypto.GenerateKey()
+	tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   common.Big1,
+		To:        &common.Address{},
+		Gas:       100000,
+		GasFeeCap: big.NewInt(100),
+		GasTipCap: big.NewInt(tip),
+		Data:      data,
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	return &txpool.LazyTransaction{
+		Hash:      tx.Hash(),
+		Tx:        tx,
+		Time:      tx.Time(),
+		GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+		GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+		Gas:       tx.Gas(),
+	}
+}
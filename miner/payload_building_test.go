@@ -108,7 +108,10 @@ type testWorkerBackend struct {
 func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, n int) *testWorkerBackend {
 	var gspec = &core.Genesis{
 		Config: chainConfig,
-		Alloc:  types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+		Alloc: types.GenesisAlloc{
+			testBankAddress: {Balance: testBankFunds},
+			testUserAddress: {Balance: testBankFunds},
+		},
 	}
 	switch e := engine.(type) {
 	case *clique.Clique:
@@ -233,6 +236,91 @@ func testBuildPayload(t *testing.T, noTxPool, interrupt bool) {
 	}
 }
 
+// Tests that transactions from priority senders are included ahead of both local
+// and remote transactions when building a block.
+func TestBuildPayloadPriorityLane(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		engine  = ethash.NewFaker()
+		backend = newTestWorkerBackend(t, params.TestChainConfig, engine, db, 0)
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	prioTx := types.MustSignNewTx(testUserKey, signer, &types.LegacyTx{
+		Nonce:    0,
+		To:       &testBankAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	backend.txPool.Add(pendingTxs, true, true)
+	backend.txPool.Add([]*types.Transaction{prioTx}, false, true)
+
+	config := testConfig
+	config.PrioritySenders = []common.Address{testUserAddress}
+	w := New(backend, config, engine)
+
+	result := w.generateWork(&generateParams{
+		parentHash: backend.chain.CurrentBlock().Hash(),
+		timestamp:  uint64(time.Now().Unix()),
+		coinbase:   testBankAddress,
+	})
+	if result.err != nil {
+		t.Fatalf("failed to generate work: %v", result.err)
+	}
+	txs := result.block.Transactions()
+	if len(txs) != len(pendingTxs)+1 {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(txs), len(pendingTxs)+1)
+	}
+	if txs[0].Hash() != prioTx.Hash() {
+		t.Fatalf("priority transaction not first: have %x, want %x", txs[0].Hash(), prioTx.Hash())
+	}
+}
+
+func TestBuildPayloadSenderCap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		engine  = ethash.NewFaker()
+		backend = newTestWorkerBackend(t, params.TestChainConfig, engine, db, 0)
+		signer  = types.LatestSigner(params.TestChainConfig)
+	)
+	userTx := types.MustSignNewTx(testUserKey, signer, &types.LegacyTx{
+		Nonce:    0,
+		To:       &testBankAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	backend.txPool.Add(genTxs(0, 3), false, true)
+	backend.txPool.Add([]*types.Transaction{userTx}, false, true)
+
+	build := func(config Config) types.Transactions {
+		result := New(backend, config, engine).generateWork(&generateParams{
+			parentHash: backend.chain.CurrentBlock().Hash(),
+			timestamp:  uint64(time.Now().Unix()),
+			coinbase:   testBankAddress,
+		})
+		if result.err != nil {
+			t.Fatalf("failed to generate work: %v", result.err)
+		}
+		return result.block.Transactions()
+	}
+	// Only two transactions of the bank should make it into the block
+	config := testConfig
+	config.MaxTxsPerSender = 2
+	if txs := build(config); len(txs) != 3 {
+		t.Fatalf("capped transaction count mismatch: have %d, want %d", len(txs), 3)
+	}
+	// Exempt senders should be able to fill the block regardless
+	config.SenderCapExempt = []common.Address{testBankAddress}
+	if txs := build(config); len(txs) != 4 {
+		t.Fatalf("exempt transaction count mismatch: have %d, want %d", len(txs), 4)
+	}
+}
+
 func genTxs(startNonce, count uint64) types.Transactions {
 	txs := make(types.Transactions, 0, count)
 	signer := types.LatestSigner(params.TestChainConfig)
@@ -67,6 +67,7 @@ type environment struct {
 	receipts []*types.Receipt
 	sidecars []*types.BlobTxSidecar
 	blobs    int
+	senders  map[common.Address]uint64 // Number of transactions included from each sender
 }
 
 const (
@@ -283,6 +284,7 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 		coinbase: coinbase,
 		header:   header,
 		freeGasLeft: make(map[common.Address]uint64),
+		senders:  make(map[common.Address]uint64),
 	}, nil
 }
 
@@ -344,6 +346,14 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
+	miner.confMu.RLock()
+	senderCap := miner.config.MaxTxsPerSender
+	exempt := make(map[common.Address]bool, len(miner.config.SenderCapExempt))
+	for _, account := range miner.config.SenderCapExempt {
+		exempt[account] = true
+	}
+	miner.confMu.RUnlock()
+
 	for {
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
@@ -408,6 +418,14 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// during transaction acceptance in the transaction pool.
 		from, _ := types.Sender(env.signer, tx)
 
+		// If the sender already filled its share of the block, skip the rest of
+		// its transactions so a burst from one account can't starve the others.
+		if senderCap > 0 && env.senders[from] >= senderCap && !exempt[from] {
+			log.Trace("Sender inclusion cap reached", "hash", ltx.Hash, "sender", from, "cap", senderCap)
+			txs.Pop()
+			continue
+		}
+
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
@@ -443,6 +461,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
+			env.senders[from]++
 			txs.Shift()
 
 		default:
@@ -461,8 +480,22 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
 	miner.confMu.RLock()
 	tip := miner.config.GasPrice
+	prio := miner.config.PrioritySenders
+	scoring := miner.config.TxScoring
 	miner.confMu.RUnlock()
 
+	// On rollups, rank the transactions by what they earn net of their L1 data
+	// cost, unless a custom scoring policy is configured
+	var l1CostFn txpool.L1CostFunc
+	if costFn := types.NewL1CostFunc(miner.chainConfig, env.state); costFn != nil {
+		if scoring == nil {
+			scoring = txpool.NetTipScoring()
+		}
+		l1CostFn = func(rollupCostData types.RollupCostData) *big.Int {
+			return costFn(rollupCostData, env.header.Time)
+		}
+	}
+
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(tip),
@@ -479,10 +512,21 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
 	pendingBlobTxs := miner.txpool.Pending(filter)
 
-	// Split the pending transactions into locals and remotes.
+	// Split the pending transactions into priority senders, locals and remotes.
+	prioPlainTxs, prioBlobTxs := make(map[common.Address][]*txpool.LazyTransaction), make(map[common.Address][]*txpool.LazyTransaction)
 	localPlainTxs, remotePlainTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
 	localBlobTxs, remoteBlobTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingBlobTxs
 
+	for _, account := range prio {
+		if txs := remotePlainTxs[account]; len(txs) > 0 {
+			delete(remotePlainTxs, account)
+			prioPlainTxs[account] = txs
+		}
+		if txs := remoteBlobTxs[account]; len(txs) > 0 {
+			delete(remoteBlobTxs, account)
+			prioBlobTxs[account] = txs
+		}
+	}
 	for _, account := range miner.txpool.Locals() {
 		if txs := remotePlainTxs[account]; len(txs) > 0 {
 			delete(remotePlainTxs, account)
@@ -493,18 +537,27 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 			localBlobTxs[account] = txs
 		}
 	}
-	// Fill the block with all available pending transactions.
+	// Fill the block with all available pending transactions, starting with the
+	// priority lane.
+	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
+		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee, scoring, l1CostFn)
+		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee, scoring, l1CostFn)
+
+		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
+			return err
+		}
+	}
 	if len(localPlainTxs) > 0 || len(localBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee)
+		plainTxs := newTransactionsByPriceAndNonce(env.signer, localPlainTxs, env.header.BaseFee, scoring, l1CostFn)
+		blobTxs := newTransactionsByPriceAndNonce(env.signer, localBlobTxs, env.header.BaseFee, scoring, l1CostFn)
 
 		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
 			return err
 		}
 	}
 	if len(remotePlainTxs) > 0 || len(remoteBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee)
+		plainTxs := newTransactionsByPriceAndNonce(env.signer, remotePlainTxs, env.header.BaseFee, scoring, l1CostFn)
+		blobTxs := newTransactionsByPriceAndNonce(env.signer, remoteBlobTxs, env.header.BaseFee, scoring, l1CostFn)
 
 		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
 			return err